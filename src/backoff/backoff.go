@@ -0,0 +1,51 @@
+// Package backoff computes delays between retries of a failing operation
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff computes exponentially growing delays with jitter, limited by a max number of attempts
+type Backoff struct {
+	minDelay    time.Duration
+	maxDelay    time.Duration
+	maxAttempts int
+	attempt     int
+	rand        *rand.Rand
+}
+
+// New creates a backoff, maxAttempts lower than one means that attempts are never exhausted
+func New(minDelay, maxDelay time.Duration, maxAttempts int) *Backoff {
+	return &Backoff{
+		minDelay:    minDelay,
+		maxDelay:    maxDelay,
+		maxAttempts: maxAttempts,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Next returns the delay before the next attempt, false is returned when the budget is exhausted
+func (b *Backoff) Next() (time.Duration, bool) {
+	if b.maxAttempts > 0 && b.attempt >= b.maxAttempts {
+		return 0, false
+	}
+
+	delay := b.minDelay << b.attempt
+	if delay > b.maxDelay || delay <= 0 {
+		delay = b.maxDelay
+	}
+	b.attempt++
+
+	// equal jitter, the delay is always at least a half of the computed value
+	half := delay / 2
+	return half + time.Duration(b.rand.Int63n(int64(half)+1)), true
+}
+
+func (b *Backoff) Attempt() int {
+	return b.attempt
+}
+
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,38 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	b := New(time.Second, 4*time.Second, 4)
+
+	for _, maxDelay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		delay, ok := b.Next()
+		require.True(t, ok)
+		require.GreaterOrEqual(t, delay, maxDelay/2)
+		require.LessOrEqual(t, delay, maxDelay)
+	}
+
+	_, ok := b.Next()
+	require.False(t, ok)
+	require.Equal(t, 4, b.Attempt())
+
+	b.Reset()
+	delay, ok := b.Next()
+	require.True(t, ok)
+	require.LessOrEqual(t, delay, time.Second)
+}
+
+func TestBackoffUnlimited(t *testing.T) {
+	b := New(time.Second, 4*time.Second, 0)
+
+	for i := 0; i < 100; i++ {
+		delay, ok := b.Next()
+		require.True(t, ok)
+		require.LessOrEqual(t, delay, 4*time.Second)
+	}
+}
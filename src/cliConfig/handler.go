@@ -0,0 +1,256 @@
+// Package cliConfig manages the user config file and the project config file with default values of flags
+package cliConfig
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+// EnvPrefix is shared by env variables of all flags, e.g. ZEROPS_PROJECT_ID for --projectId
+const EnvPrefix = "ZEROPS"
+
+// projectFileName is searched from the working directory up to the repository root
+const projectFileName = "zcli.config"
+
+// Handler reads the user config file and the project one, values of the project file take precedence
+type Handler struct {
+	userFilePath    string
+	projectFilePath string
+	user            *viper.Viper
+	project         *viper.Viper
+}
+
+// Value is an effective config value, Origin is the file path or the env variable it comes from
+type Value struct {
+	Key    string
+	Value  string
+	Origin string
+}
+
+func Load() (*Handler, error) {
+	userFilePath, err := constants.CliConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		userFilePath:    userFilePath,
+		projectFilePath: findProjectFile(),
+		user:            viper.New(),
+		project:         viper.New(),
+	}
+
+	if err := readFile(h.user, h.userFilePath); err != nil {
+		return nil, err
+	}
+	if h.projectFilePath != "" {
+		if err := readFile(h.project, h.projectFilePath); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Key converts a flag name to its config key, e.g. projectId to project_id and on-match to on_match,
+// the key is usable as a POSIX env variable name
+func Key(flagName string) string {
+	var result string
+	for i, r := range flagName {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result += "_"
+		}
+		if r == '-' {
+			r = '_'
+		}
+		result += string(r)
+	}
+	return strings.ToLower(result)
+}
+
+// EnvName returns the env variable overriding the config key
+func EnvName(key string) string {
+	return strings.ToUpper(EnvPrefix + "_" + key)
+}
+
+func (h *Handler) UserFilePath() string {
+	return h.userFilePath
+}
+
+// ProjectFilePath is empty if there is no project config file
+func (h *Handler) ProjectFilePath() string {
+	return h.projectFilePath
+}
+
+// MergeInto adds values of both files to the config layer of the viper, the project values override the user ones
+func (h *Handler) MergeInto(v *viper.Viper) error {
+	if err := v.MergeConfigMap(h.user.AllSettings()); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(v.MergeConfigMap(h.project.AllSettings()))
+}
+
+// List returns effective values of all keys set in the config files sorted by the key
+func (h *Handler) List() []Value {
+	keys := make(map[string]bool)
+	for _, key := range append(h.user.AllKeys(), h.project.AllKeys()...) {
+		keys[key] = true
+	}
+
+	values := make([]Value, 0, len(keys))
+	for key := range keys {
+		value, _ := h.Get(key)
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Key < values[j].Key
+	})
+	return values
+}
+
+// Get returns the effective value of the key, the env variable overrides both files
+func (h *Handler) Get(key string) (Value, bool) {
+	key = Key(key)
+
+	if env, exists := os.LookupEnv(EnvName(key)); exists {
+		return Value{Key: key, Value: env, Origin: i18n.T(i18n.ConfigOriginEnv, EnvName(key))}, true
+	}
+	if h.project.IsSet(key) {
+		return Value{Key: key, Value: cast.ToString(h.project.Get(key)), Origin: h.projectFilePath}, true
+	}
+	if h.user.IsSet(key) {
+		return Value{Key: key, Value: cast.ToString(h.user.Get(key)), Origin: h.userFilePath}, true
+	}
+	return Value{Key: key}, false
+}
+
+// Set writes the value into the user config file, or into the project one which is created in the repository root if needed.
+// The path of the written file is returned.
+func (h *Handler) Set(key, value string, project bool) (string, error) {
+	key = Key(key)
+
+	if project && h.projectFilePath == "" {
+		h.projectFilePath = filepath.Join(findProjectRoot(), projectFileName+".yaml")
+	}
+
+	return h.update(project, func(settings map[string]any) {
+		settings[key] = value
+	})
+}
+
+// Unset removes the key from the user config file or the project one, the path of the written file is returned
+func (h *Handler) Unset(key string, project bool) (string, error) {
+	key = Key(key)
+
+	v := h.user
+	if project {
+		v = h.project
+	}
+	if !v.IsSet(key) {
+		return "", errors.New(i18n.T(i18n.ConfigKeyNotSet, key))
+	}
+
+	return h.update(project, func(settings map[string]any) {
+		delete(settings, key)
+	})
+}
+
+// update replaces the content of the user or the project file by the modified settings
+func (h *Handler) update(project bool, modify func(settings map[string]any)) (string, error) {
+	v, filePath := h.user, h.userFilePath
+	if project {
+		v, filePath = h.project, h.projectFilePath
+	}
+
+	settings := v.AllSettings()
+	modify(settings)
+	v, err := writeFile(filePath, settings)
+	if err != nil {
+		return filePath, err
+	}
+
+	if project {
+		h.project = v
+	} else {
+		h.user = v
+	}
+	return filePath, nil
+}
+
+func readFile(v *viper.Viper, filePath string) error {
+	v.SetConfigFile(filePath)
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return errors.WithMessagef(err, i18n.T(i18n.ConfigFileInvalid, filePath))
+	}
+	return nil
+}
+
+// writeFile replaces the file content by the settings, the format is given by the file extension
+func writeFile(filePath string, settings map[string]any) (*viper.Viper, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(filePath)
+	if err := v.MergeConfigMap(settings); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := v.WriteConfigAs(filePath); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return v, nil
+}
+
+// findProjectFile looks for zcli.config.* in the working directory and its parents up to the repository root
+func findProjectFile() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, ext := range viper.SupportedExts {
+			filePath := filepath.Join(dir, projectFileName+"."+ext)
+			if _, err := os.Stat(filePath); err == nil {
+				return filePath
+			}
+		}
+		if isRepositoryRoot(dir) || filepath.Dir(dir) == dir {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// findProjectRoot returns the repository root, the working directory outside of a repository
+func findProjectRoot() string {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := workDir; ; dir = filepath.Dir(dir) {
+		if isRepositoryRoot(dir) {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return workDir
+		}
+	}
+}
+
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
@@ -0,0 +1,59 @@
+package cliConfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zeropsio/zcli/src/constants"
+)
+
+func TestKey(t *testing.T) {
+	require.Equal(t, "project_id", Key("projectId"))
+	require.Equal(t, "project_id", Key("project_id"))
+	require.Equal(t, "on_match", Key("on-match"))
+	require.Equal(t, "ZEROPS_ALERT_RATE_LIMIT", EnvName(Key("alertRateLimit")))
+	require.Equal(t, "ZEROPS_PROJECT_ID", EnvName(Key("projectId")))
+}
+
+func TestHandler(t *testing.T) {
+	dir := t.TempDir()
+	userFilePath := filepath.Join(dir, "user", constants.CliConfigFileName)
+	t.Setenv(constants.CliConfigFilePathEnvVar, userFilePath)
+
+	repoDir := filepath.Join(dir, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "app"), 0755))
+
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(repoDir, "app")))
+	defer os.Chdir(workDir)
+
+	config, err := Load()
+	require.NoError(t, err)
+	require.Equal(t, "", config.ProjectFilePath())
+
+	_, err = config.Set("projectId", "user", false)
+	require.NoError(t, err)
+	filePath, err := config.Set("projectId", "project", true)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repoDir, "zcli.config.yaml"), filePath)
+
+	// the project file overrides the user one
+	config, err = Load()
+	require.NoError(t, err)
+	value, found := config.Get("projectId")
+	require.True(t, found)
+	require.Equal(t, Value{Key: "project_id", Value: "project", Origin: filePath}, value)
+
+	_, err = config.Unset("projectId", true)
+	require.NoError(t, err)
+	value, _ = config.Get("projectId")
+	require.Equal(t, Value{Key: "project_id", Value: "user", Origin: userFilePath}, value)
+
+	t.Setenv("ZEROPS_PROJECT_ID", "env")
+	require.Equal(t, []Value{{Key: "project_id", Value: "env", Origin: "env ZEROPS_PROJECT_ID"}}, config.List())
+}
@@ -0,0 +1,130 @@
+package cliStorage
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+const DefaultContextName = "default"
+
+var contextNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ContextName returns the context selected for this invocation, the current one otherwise
+func (h *Handler) ContextName() string {
+	if h.contextName != "" {
+		return h.contextName
+	}
+	if current := h.Handler.Data().CurrentContext; current != "" {
+		return current
+	}
+	return DefaultContextName
+}
+
+// SelectContext overrides the current context for this invocation without storing it
+func (h *Handler) SelectContext(name string) error {
+	if !h.contextExists(name) {
+		return errors.New(i18n.T(i18n.ContextNotFound, name))
+	}
+	h.contextName = name
+	return nil
+}
+
+// ContextNames returns all contexts sorted by the name, including the default one
+func (h *Handler) ContextNames() []string {
+	names := []string{DefaultContextName}
+	for name := range h.Handler.Data().Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// LoginContext returns the stored values of the named context
+func (h *Handler) LoginContext(name string) LoginContext {
+	return swapContext(h.Handler.Data(), DefaultContextName, name).loginContext()
+}
+
+// AddContext creates an empty context and makes it the current one
+func (h *Handler) AddContext(name string) error {
+	if !contextNameRegexp.MatchString(name) {
+		return errors.New(i18n.T(i18n.ContextInvalidName, name))
+	}
+	if h.contextExists(name) {
+		return errors.New(i18n.T(i18n.ContextAlreadyExists, name))
+	}
+	_, err := h.Handler.Update(func(data Data) Data {
+		contexts := make(map[string]LoginContext, len(data.Contexts)+1)
+		for contextName, loginContext := range data.Contexts {
+			contexts[contextName] = loginContext
+		}
+		contexts[name] = LoginContext{}
+		data.Contexts = contexts
+		data.CurrentContext = name
+		return data
+	})
+	h.contextName = ""
+	return err
+}
+
+// UseContext makes the named context the current one
+func (h *Handler) UseContext(name string) error {
+	if !h.contextExists(name) {
+		return errors.New(i18n.T(i18n.ContextNotFound, name))
+	}
+	_, err := h.Handler.Update(func(data Data) Data {
+		data.CurrentContext = name
+		if name == DefaultContextName {
+			data.CurrentContext = ""
+		}
+		return data
+	})
+	h.contextName = ""
+	return err
+}
+
+func (h *Handler) contextExists(name string) bool {
+	if name == DefaultContextName {
+		return true
+	}
+	_, exists := h.Handler.Data().Contexts[name]
+	return exists
+}
+
+func (d Data) loginContext() LoginContext {
+	return LoginContext{
+		Token:          d.Token,
+		RegionData:     d.RegionData,
+		ScopeProjectId: d.ScopeProjectId,
+		VpnKeys:        d.VpnKeys,
+	}
+}
+
+func (d Data) withLoginContext(loginContext LoginContext) Data {
+	d.Token = loginContext.Token
+	d.RegionData = loginContext.RegionData
+	d.ScopeProjectId = loginContext.ScopeProjectId
+	d.VpnKeys = loginContext.VpnKeys
+	return d
+}
+
+// swapContext stores the top level fields as the context `from` and moves the context `to` into them
+func swapContext(data Data, from, to string) Data {
+	if from == to {
+		return data
+	}
+	contexts := make(map[string]LoginContext, len(data.Contexts)+1)
+	for name, loginContext := range data.Contexts {
+		contexts[name] = loginContext
+	}
+	contexts[from] = data.loginContext()
+	selected := contexts[to]
+	delete(contexts, to)
+
+	data = data.withLoginContext(selected)
+	data.Contexts = contexts
+	return data
+}
@@ -0,0 +1,82 @@
+package cliStorage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zeropsio/zcli/src/region"
+	"github.com/zeropsio/zcli/src/storage"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+func TestContexts(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "cli.data")
+
+	s, err := storage.New[Data](storage.Config{FilePath: filePath, FileMode: 0600})
+	require.NoError(t, err)
+	handler := &Handler{Handler: s}
+
+	_, err = handler.Update(func(data Data) Data {
+		data.Token = "default-token"
+		return data
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, handler.AddContext("client"))
+	require.Equal(t, "client", handler.ContextName())
+	require.Equal(t, "", handler.Data().Token)
+
+	_, err = handler.Update(func(data Data) Data {
+		data.Token = "client-token"
+		return data
+	})
+	require.NoError(t, err)
+	require.Equal(t, "client-token", handler.Data().Token)
+	require.Equal(t, "default-token", handler.LoginContext(DefaultContextName).Token)
+
+	// the override is not stored
+	require.NoError(t, handler.SelectContext(DefaultContextName))
+	require.Equal(t, "default-token", handler.Data().Token)
+	require.Error(t, handler.SelectContext("missing"))
+
+	s, err = storage.New[Data](storage.Config{FilePath: filePath})
+	require.NoError(t, err)
+	handler = &Handler{Handler: s}
+	require.Equal(t, "client", handler.ContextName())
+	require.Equal(t, "client-token", handler.Data().Token)
+	require.Equal(t, []string{DefaultContextName, "client"}, handler.ContextNames())
+
+	_, secrets := handler.Handler.Data().WithoutSecrets()
+	require.Equal(t, map[string]string{
+		"token":                "default-token",
+		"context/client/token": "client-token",
+	}, secrets)
+}
+
+func TestEnvLogin(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "cli.data")
+
+	s, err := storage.New[Data](storage.Config{FilePath: filePath, FileMode: 0600})
+	require.NoError(t, err)
+	handler := &Handler{Handler: s}
+
+	_, err = handler.Update(func(data Data) Data {
+		data.Token = "stored-token"
+		return data
+	})
+	require.NoError(t, err)
+
+	handler.SetEnvLogin("env-token", region.RegionItem{Name: "env"})
+	require.Equal(t, "env-token", handler.Data().Token)
+
+	d, err := handler.Update(func(data Data) Data {
+		data.ScopeProjectId = uuid.ProjectId("project").ProjectIdNull()
+		return data
+	})
+	require.NoError(t, err)
+	require.Equal(t, "env-token", d.Token)
+	require.Equal(t, "stored-token", handler.Handler.Data().Token)
+	require.Equal(t, "", handler.Handler.Data().RegionData.Name)
+}
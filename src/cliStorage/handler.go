@@ -9,11 +9,85 @@ import (
 
 type Handler struct {
 	*storage.Handler[Data]
+
+	// contextName overrides the current context for a single invocation
+	contextName string
+	// envLogin is the token and region given by env variables, it is never stored
+	envLogin *LoginContext
 }
 
 type Data struct {
+	// Token, RegionData, ScopeProjectId and VpnKeys belong to the selected context,
+	// the default context is stored in them and the other ones in Contexts
+	Token          string
+	RegionData     region.RegionItem
+	ScopeProjectId uuid.ProjectIdNull
+	VpnKeys        map[uuid.ProjectId]entity.VpnKey
+	VpnTunnels     map[uuid.ProjectId]entity.VpnTunnel
+	// RegionCache is shared by all contexts
+	RegionCache region.Cache
+	// SecretStore is where the token and VPN keys are kept, they are in this file if it is empty
+	SecretStore string
+	// SavedSecretKeys are the secrets saved to SecretStore, a missing one is an error instead of a silent logout
+	SavedSecretKeys []string `json:",omitempty"`
+
+	CurrentContext string
+	Contexts       map[string]LoginContext
+}
+
+// LoginContext is a named login, each one has its own token, region, project scope and VPN keys
+type LoginContext struct {
 	Token          string
 	RegionData     region.RegionItem
 	ScopeProjectId uuid.ProjectIdNull
 	VpnKeys        map[uuid.ProjectId]entity.VpnKey
 }
+
+// Data returns the stored data with the selected context in the top level fields
+func (h *Handler) Data() Data {
+	return h.withEnvLogin(swapContext(h.Handler.Data(), DefaultContextName, h.ContextName()))
+}
+
+// Update passes the data with the selected context to the callback and stores the context back to its place.
+// The env login is kept out of the file unless the callback changes it.
+func (h *Handler) Update(callback func(Data) Data) (Data, error) {
+	contextName := h.ContextName()
+	data, err := h.Handler.Update(func(data Data) Data {
+		data = swapContext(data, DefaultContextName, contextName)
+		stored := data.loginContext()
+
+		data = callback(h.withEnvLogin(data))
+		if h.envLogin != nil {
+			if data.Token == h.envLogin.Token {
+				data.Token = stored.Token
+			}
+			if data.RegionData == h.envLogin.RegionData {
+				data.RegionData = stored.RegionData
+			}
+		}
+
+		return swapContext(data, contextName, DefaultContextName)
+	})
+	return h.withEnvLogin(swapContext(data, DefaultContextName, contextName)), err
+}
+
+// SetEnvLogin overrides the stored token and region for this invocation without storing them
+func (h *Handler) SetEnvLogin(token string, regionData region.RegionItem) {
+	h.envLogin = &LoginContext{
+		Token:      token,
+		RegionData: regionData,
+	}
+}
+
+// IsEnvLogin reports whether the token comes from an env variable
+func (h *Handler) IsEnvLogin() bool {
+	return h.envLogin != nil
+}
+
+func (h *Handler) withEnvLogin(data Data) Data {
+	if h.envLogin != nil {
+		data.Token = h.envLogin.Token
+		data.RegionData = h.envLogin.RegionData
+	}
+	return data
+}
@@ -0,0 +1,13 @@
+package cliStorage
+
+import (
+	"github.com/zeropsio/zcli/src/storage"
+)
+
+// Migrations upgrade cli.data written by older zcli versions, a new one is appended with every incompatible change
+var Migrations = []storage.Migration{
+	// 0 -> 1: files written before the schema version was introduced, the layout is the same
+	func(data map[string]any) error {
+		return nil
+	},
+}
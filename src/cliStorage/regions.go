@@ -0,0 +1,29 @@
+package cliStorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeropsio/zcli/src/region"
+)
+
+// RetrieveRegions returns the region list through the cache kept in the data file, see region.Handler.RetrieveAllCached
+func (h *Handler) RetrieveRegions(
+	ctx context.Context,
+	regionRetriever *region.Handler,
+	regionUrl string,
+	maxAge time.Duration,
+) ([]region.RegionItem, error) {
+	storedCache := h.Handler.Data().RegionCache
+	regions, cache, err := regionRetriever.RetrieveAllCached(ctx, regionUrl, storedCache, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	if !cache.RetrievedAt.Equal(storedCache.RetrievedAt) {
+		_, err = h.Handler.Update(func(data Data) Data {
+			data.RegionCache = cache
+			return data
+		})
+	}
+	return regions, err
+}
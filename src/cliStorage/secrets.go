@@ -0,0 +1,118 @@
+package cliStorage
+
+import (
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+const (
+	tokenSecretKey        = "token"
+	vpnKeySecretKeyPrefix = "vpnKey/"
+	// secrets of the default context have no prefix, so that the keys of older files stay valid
+	contextSecretKeyPrefix = "context/"
+)
+
+func (d Data) SecretStoreName() string {
+	return d.SecretStore
+}
+
+func (d Data) WithSecretStoreName(name string) Data {
+	d.SecretStore = name
+	return d
+}
+
+func (d Data) SecretKeys() []string {
+	return d.SavedSecretKeys
+}
+
+func (d Data) WithSecretKeys(keys []string) Data {
+	d.SavedSecretKeys = keys
+	return d
+}
+
+// WithoutSecrets removes tokens and private VPN keys of all contexts,
+// the maps are copied so that the original data stays intact
+func (d Data) WithoutSecrets() (Data, map[string]string) {
+	secrets := make(map[string]string)
+
+	d = d.withLoginContext(d.loginContext().withoutSecrets("", secrets))
+
+	if d.Contexts != nil {
+		contexts := make(map[string]LoginContext, len(d.Contexts))
+		for name, loginContext := range d.Contexts {
+			contexts[name] = loginContext.withoutSecrets(contextSecretKeyPrefix+name+"/", secrets)
+		}
+		d.Contexts = contexts
+	}
+
+	return d, secrets
+}
+
+func (d Data) WithSecrets(get func(key string) (string, error)) (Data, error) {
+	loginContext, err := d.loginContext().withSecrets("", get)
+	if err != nil {
+		return d, err
+	}
+	d = d.withLoginContext(loginContext)
+
+	if d.Contexts != nil {
+		contexts := make(map[string]LoginContext, len(d.Contexts))
+		for name, loginContext := range d.Contexts {
+			contexts[name], err = loginContext.withSecrets(contextSecretKeyPrefix+name+"/", get)
+			if err != nil {
+				return d, err
+			}
+		}
+		d.Contexts = contexts
+	}
+
+	return d, nil
+}
+
+func (c LoginContext) withoutSecrets(keyPrefix string, secrets map[string]string) LoginContext {
+	if c.Token != "" {
+		secrets[keyPrefix+tokenSecretKey] = c.Token
+		c.Token = ""
+	}
+
+	if c.VpnKeys != nil {
+		vpnKeys := make(map[uuid.ProjectId]entity.VpnKey, len(c.VpnKeys))
+		for projectId, vpnKey := range c.VpnKeys {
+			if vpnKey.Key != "" {
+				secrets[keyPrefix+vpnKeySecretKeyPrefix+string(projectId)] = vpnKey.Key
+				vpnKey.Key = ""
+			}
+			vpnKeys[projectId] = vpnKey
+		}
+		c.VpnKeys = vpnKeys
+	}
+
+	return c
+}
+
+func (c LoginContext) withSecrets(keyPrefix string, get func(key string) (string, error)) (LoginContext, error) {
+	if c.Token == "" {
+		token, err := get(keyPrefix + tokenSecretKey)
+		if err != nil {
+			return c, err
+		}
+		c.Token = token
+	}
+
+	if c.VpnKeys != nil {
+		vpnKeys := make(map[uuid.ProjectId]entity.VpnKey, len(c.VpnKeys))
+		for projectId, vpnKey := range c.VpnKeys {
+			if vpnKey.Key == "" {
+				key, err := get(keyPrefix + vpnKeySecretKeyPrefix + string(projectId))
+				if err != nil {
+					return c, err
+				}
+				vpnKey.Key = key
+			}
+			vpnKeys[projectId] = vpnKey
+		}
+		c.VpnKeys = vpnKeys
+	}
+
+	return c, nil
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+func authCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("auth").
+		Short(i18n.T(i18n.CmdDescAuth)).
+		HelpFlag(i18n.T(i18n.CmdHelpAuth)).
+		AddChildrenCmd(authStatusCmd())
+}
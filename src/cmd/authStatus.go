@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/secretStore"
+	"github.com/zeropsio/zcli/src/uxBlock"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func authStatusCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("status").
+		Short(i18n.T(i18n.CmdDescAuthStatus)).
+		HelpFlag(i18n.T(i18n.CmdHelpAuthStatus)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			data := cmdData.CliStorage.Data()
+
+			cliDataFilePath, _, err := constants.CliDataFilePath()
+			if err != nil {
+				cliDataFilePath = err.Error()
+			}
+
+			loggedIn := i18n.T(i18n.AuthStatusNo)
+			if data.Token != "" {
+				loggedIn = i18n.T(i18n.AuthStatusYes)
+			}
+			region := data.RegionData.Name
+			if region == "" {
+				region = "-"
+			}
+
+			storeName := cmdData.CliStorage.SecretStoreName()
+			var secrets string
+			switch storeName {
+			case secretStore.NameKeyring:
+				secrets = i18n.T(i18n.AuthStatusSecretsKeyring)
+			case secretStore.NameKernelKeyring:
+				secrets = i18n.T(i18n.AuthStatusSecretsKernel)
+			case secretStore.NameFile:
+				secrets = i18n.T(i18n.AuthStatusSecretsFile, cliDataFilePath+".secrets")
+			default:
+				secrets = i18n.T(i18n.AuthStatusSecretsPlain, cliDataFilePath)
+			}
+
+			body := &uxBlock.TableBody{}
+			body.AddStringsRow(i18n.T(i18n.StatusInfoContext), cmdData.CliStorage.ContextName())
+			body.AddStringsRow(i18n.T(i18n.AuthStatusLoggedIn), loggedIn)
+			if data.Token != "" {
+				body.AddStringsRow(i18n.T(i18n.StatusInfoToken), tokenSourceText(cmdData.CliStorage))
+			}
+			body.AddStringsRow(i18n.T(i18n.AuthStatusRegion), region)
+			body.AddStringsRow(i18n.T(i18n.AuthStatusSecrets), secrets)
+			body.AddStringsRow(i18n.T(i18n.AuthStatusVpnKeys), fmt.Sprintf("%d", len(data.VpnKeys)))
+			body.AddStringsRow(i18n.T(i18n.StatusInfoCliDataFilePath), cliDataFilePath)
+			cmdData.UxBlocks.Table(body)
+
+			if storeName == "" && ((data.Token != "" && !cmdData.CliStorage.IsEnvLogin()) || len(data.VpnKeys) > 0) {
+				cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(
+					i18n.AuthStatusPlainWarning,
+					constants.CliSecretPassphraseEnvVar,
+				)))
+			}
+
+			return nil
+		})
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+func completionCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("completion").
+		Short(i18n.T(i18n.CmdDescCompletion)).
+		Long(i18n.T(i18n.CmdDescCompletionLong)).
+		Arg("shell", cmdBuilder.ArgCompletion(func(context.Context, *cmdBuilder.CompletionCmdData) ([]string, error) {
+			return cmdBuilder.CompletionShells, nil
+		})).
+		HelpFlag(i18n.T(i18n.CmdHelpCompletion)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			return cmdData.PrintCompletion(cmdData.Args["shell"][0])
+		})
+}
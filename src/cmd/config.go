@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+const (
+	configKeyArgName   = "key"
+	configValueArgName = "value"
+)
+
+func configCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("config").
+		Short(i18n.T(i18n.CmdDescConfig)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfig)).
+		AddChildrenCmd(configGetCmd()).
+		AddChildrenCmd(configSetCmd()).
+		AddChildrenCmd(configUnsetCmd()).
+		AddChildrenCmd(configListCmd()).
+		AddChildrenCmd(configPathCmd())
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+func configGetCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("get").
+		Short(i18n.T(i18n.CmdDescConfigGet)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfigGet)).
+		Arg(configKeyArgName).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			config, err := cliConfig.Load()
+			if err != nil {
+				return err
+			}
+
+			value, found := config.Get(cmdData.Args[configKeyArgName][0])
+			if !found {
+				return errors.New(i18n.T(i18n.ConfigKeyNotSet, value.Key))
+			}
+
+			// the plain value, so that it can be used in scripts
+			fmt.Println(value.Value)
+
+			return nil
+		})
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func configListCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("list").
+		Short(i18n.T(i18n.CmdDescConfigList)).
+		BoolFlag("show-origin", false, i18n.T(i18n.ConfigShowOriginFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfigList)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			config, err := cliConfig.Load()
+			if err != nil {
+				return err
+			}
+
+			values := config.List()
+			if len(values) == 0 {
+				cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.ConfigNoValues)))
+				return nil
+			}
+
+			showOrigin := cmdData.Params.GetBool("show-origin")
+
+			// TODO - janhajek translation
+			header := (&uxBlock.TableRow{}).AddStringCells("Key", "Value")
+			if showOrigin {
+				header.AddStringCells("Origin")
+			}
+
+			body := &uxBlock.TableBody{}
+			for _, value := range values {
+				cells := []string{value.Key, value.Value}
+				if showOrigin {
+					cells = append(cells, value.Origin)
+				}
+				body.AddStringsRow(cells...)
+			}
+
+			cmdData.UxBlocks.Table(body, uxBlock.WithTableHeader(header))
+
+			return nil
+		})
+}
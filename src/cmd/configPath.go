@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock"
+)
+
+func configPathCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("path").
+		Short(i18n.T(i18n.CmdDescConfigPath)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfigPath)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			config, err := cliConfig.Load()
+			if err != nil {
+				return err
+			}
+
+			projectFilePath := config.ProjectFilePath()
+			if projectFilePath == "" {
+				projectFilePath = "-"
+			}
+
+			body := &uxBlock.TableBody{}
+			body.AddStringsRow(i18n.T(i18n.ConfigPathUser), config.UserFilePath())
+			body.AddStringsRow(i18n.T(i18n.ConfigPathProject), projectFilePath)
+			cmdData.UxBlocks.Table(body)
+
+			return nil
+		})
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func configSetCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("set").
+		Short(i18n.T(i18n.CmdDescConfigSet)).
+		BoolFlag("project", false, i18n.T(i18n.ConfigProjectFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfigSet)).
+		Arg(configKeyArgName).
+		Arg(configValueArgName).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			config, err := cliConfig.Load()
+			if err != nil {
+				return err
+			}
+
+			key := cliConfig.Key(cmdData.Args[configKeyArgName][0])
+			filePath, err := config.Set(key, cmdData.Args[configValueArgName][0], cmdData.Params.GetBool("project"))
+			if err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.ConfigValueSet, key, filePath)))
+
+			return nil
+		})
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func configUnsetCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("unset").
+		Short(i18n.T(i18n.CmdDescConfigUnset)).
+		BoolFlag("project", false, i18n.T(i18n.ConfigProjectFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpConfigUnset)).
+		Arg(configKeyArgName).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			config, err := cliConfig.Load()
+			if err != nil {
+				return err
+			}
+
+			key := cliConfig.Key(cmdData.Args[configKeyArgName][0])
+			filePath, err := config.Unset(key, cmdData.Params.GetBool("project"))
+			if err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.ConfigValueUnset, key, filePath)))
+
+			return nil
+		})
+}
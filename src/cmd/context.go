@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+const contextNameArgName = "name"
+
+func contextCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("context").
+		Short(i18n.T(i18n.CmdDescContext)).
+		HelpFlag(i18n.T(i18n.CmdHelpContext)).
+		AddChildrenCmd(contextAddCmd()).
+		AddChildrenCmd(contextUseCmd()).
+		AddChildrenCmd(contextListCmd()).
+		AddChildrenCmd(contextCurrentCmd())
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func contextAddCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("add").
+		Short(i18n.T(i18n.CmdDescContextAdd)).
+		HelpFlag(i18n.T(i18n.CmdHelpContextAdd)).
+		Arg(contextNameArgName).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			name := cmdData.Args[contextNameArgName][0]
+
+			if err := cmdData.CliStorage.AddContext(name); err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.ContextAdded, name)))
+
+			return nil
+		})
+}
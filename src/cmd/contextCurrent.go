@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+func contextCurrentCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("current").
+		Short(i18n.T(i18n.CmdDescContextCurrent)).
+		HelpFlag(i18n.T(i18n.CmdHelpContextCurrent)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			// the plain name, so that it can be used in scripts
+			fmt.Println(cmdData.CliStorage.ContextName())
+
+			return nil
+		})
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock"
+)
+
+func contextListCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("list").
+		Short(i18n.T(i18n.CmdDescContextList)).
+		HelpFlag(i18n.T(i18n.CmdHelpContextList)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			currentName := cmdData.CliStorage.ContextName()
+
+			// TODO - janhajek translation
+			header := (&uxBlock.TableRow{}).AddStringCells("", "Name", "Region", "Scoped project", "VPN keys")
+
+			body := &uxBlock.TableBody{}
+			for _, name := range cmdData.CliStorage.ContextNames() {
+				loginContext := cmdData.CliStorage.LoginContext(name)
+
+				current := ""
+				if name == currentName {
+					current = "*"
+				}
+				region := loginContext.RegionData.Name
+				if loginContext.Token == "" {
+					region = i18n.T(i18n.ContextNotLoggedIn)
+				}
+				scopedProject := "-"
+				if projectId, filled := loginContext.ScopeProjectId.Get(); filled {
+					scopedProject = string(projectId)
+				}
+
+				body.AddStringsRow(
+					current,
+					name,
+					region,
+					scopedProject,
+					fmt.Sprintf("%d", len(loginContext.VpnKeys)),
+				)
+			}
+
+			cmdData.UxBlocks.Table(body, uxBlock.WithTableHeader(header))
+
+			return nil
+		})
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func contextUseCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("use").
+		Short(i18n.T(i18n.CmdDescContextUse)).
+		HelpFlag(i18n.T(i18n.CmdHelpContextUse)).
+		Arg(contextNameArgName).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			name := cmdData.Args[contextNameArgName][0]
+
+			if err := cmdData.CliStorage.UseContext(name); err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.ContextSwitched, name)))
+
+			return nil
+		})
+}
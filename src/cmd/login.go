@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"context"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,15 +24,21 @@ func loginCmd() *cmdBuilder.Cmd {
 		Use("login").
 		Short(i18n.T(i18n.CmdDescLogin)).
 		StringFlag("regionUrl", constants.DefaultRegionUrl, i18n.T(i18n.RegionUrlFlag), cmdBuilder.HiddenFlag()).
-		StringFlag("region", "", i18n.T(i18n.RegionFlag), cmdBuilder.HiddenFlag()).
+		StringFlag("region", "", i18n.T(i18n.RegionFlag)).
+		BoolFlag("token-stdin", false, i18n.T(i18n.LoginTokenStdinFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpLogin)).
-		Arg("token").
+		Arg("token", cmdBuilder.OptionalArg()).
 		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
 			uxBlocks := cmdData.UxBlocks
 
+			token, err := getLoginToken(cmdData)
+			if err != nil {
+				return err
+			}
+
 			regionRetriever := region.New(httpClient.New(ctx, httpClient.Config{HttpTimeout: time.Minute * 5}))
 
-			regions, err := regionRetriever.RetrieveAllFromURL(ctx, cmdData.Params.GetString("regionUrl"))
+			regions, err := cmdData.CliStorage.RetrieveRegions(ctx, regionRetriever, cmdData.Params.GetString("regionUrl"), region.CacheTtl)
 			if err != nil {
 				return err
 			}
@@ -39,7 +48,7 @@ func loginCmd() *cmdBuilder.Cmd {
 				return err
 			}
 
-			restApiClient := zeropsRestApiClient.NewAuthorizedClient(cmdData.Args["token"][0], "https://"+reg.Address)
+			restApiClient := zeropsRestApiClient.NewAuthorizedClient(token, "https://"+reg.Address)
 
 			response, err := restApiClient.GetUserInfo(ctx)
 			if err != nil {
@@ -52,7 +61,7 @@ func loginCmd() *cmdBuilder.Cmd {
 			}
 
 			_, err = cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
-				data.Token = cmdData.Args["token"][0]
+				data.Token = token
 				data.RegionData = reg
 				return data
 			})
@@ -66,6 +75,29 @@ func loginCmd() *cmdBuilder.Cmd {
 		})
 }
 
+// getLoginToken reads the token from the arg or the standard input
+func getLoginToken(cmdData *cmdBuilder.GuestCmdData) (string, error) {
+	args, hasArg := cmdData.Args["token"]
+	if !cmdData.Params.GetBool("token-stdin") {
+		if !hasArg {
+			return "", errors.New(i18n.T(i18n.LoginTokenMissing))
+		}
+		return args[0], nil
+	}
+	if hasArg {
+		return "", errors.New(i18n.T(i18n.LoginTokenTwice))
+	}
+
+	token, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if strings.TrimSpace(string(token)) == "" {
+		return "", errors.New(i18n.T(i18n.LoginTokenMissing))
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
 func getLoginRegion(
 	ctx context.Context,
 	uxBlocks uxBlock.UxBlocks,
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/zeropsio/zerops-go/types/uuid"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+func logoutCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("logout").
+		Short(i18n.T(i18n.CmdDescLogout)).
+		BoolFlag("all", false, i18n.T(i18n.LogoutAllFlag)).
+		BoolFlag("revoke", false, i18n.T(i18n.LogoutRevokeFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpLogout)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			// leftovers of an expired login are removed even without the token
+			if cmdData.Params.GetBool("all") {
+				if err := clearLoginData(cmdData.CliStorage, true); err != nil {
+					return err
+				}
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.LogoutNotLoggedIn, cmdData.CliStorage.ContextName())))
+
+			return nil
+		}).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			// the env token is not stored, the stored login is not the one in use and is left alone
+			if cmdData.CliStorage.IsEnvLogin() {
+				cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.LogoutEnvToken, constants.CliTokenEnvVar, cmdData.CliStorage.ContextName())))
+				return nil
+			}
+
+			all := cmdData.Params.GetBool("all")
+
+			for _, tunnel := range loginVpnTunnels(cmdData.CliStorage) {
+				if err := disconnectVpn(ctx, cmdData, tunnel); err != nil {
+					return err
+				}
+			}
+
+			// the peers must be removed while the token is still valid
+			if all {
+				for _, vpnKey := range storedVpnKeys(cmdData.CliStorage) {
+					if err := deleteStoredVpnKey(ctx, cmdData, vpnKey); err != nil {
+						cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.LogoutVpnKeyDeleteFailed, vpnKey.ProjectName, err.Error())))
+					}
+				}
+			}
+
+			if cmdData.Params.GetBool("revoke") {
+				if err := revokeToken(ctx, cmdData); err != nil {
+					cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.LogoutRevokeFailed, err.Error())))
+				}
+			}
+
+			if err := clearLoginData(cmdData.CliStorage, all); err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.LogoutSuccess, cmdData.CliStorage.ContextName())))
+
+			return nil
+		})
+}
+
+// loginVpnTunnels returns tunnels connected by the selected context, tunnels of older versions belong to the default one
+func loginVpnTunnels(storageHandler *cliStorage.Handler) []entity.VpnTunnel {
+	contextName := storageHandler.ContextName()
+
+	var tunnels []entity.VpnTunnel
+	for _, tunnel := range storedVpnTunnels(storageHandler) {
+		tunnelContextName := tunnel.ContextName
+		if tunnelContextName == "" {
+			tunnelContextName = cliStorage.DefaultContextName
+		}
+		if tunnelContextName == contextName {
+			tunnels = append(tunnels, tunnel)
+		}
+	}
+	return tunnels
+}
+
+func revokeToken(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+	response, err := cmdData.RestApiClient.PostAuthLogout(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = response.Output()
+	return err
+}
+
+// clearLoginData removes the token of the selected context, all removes its project scope and VPN keys as well
+func clearLoginData(storageHandler *cliStorage.Handler, all bool) error {
+	_, err := storageHandler.Update(func(data cliStorage.Data) cliStorage.Data {
+		data.Token = ""
+		if all {
+			data.ScopeProjectId = uuid.ProjectIdNull{}
+			data.VpnKeys = nil
+		}
+		return data
+	})
+	return err
+}
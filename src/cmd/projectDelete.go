@@ -17,7 +17,7 @@ func projectDeleteCmd() *cmdBuilder.Cmd {
 		Use("delete").
 		Short(i18n.T(i18n.CmdDescProjectDelete)).
 		ScopeLevel(scope.Project).
-		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
 		BoolFlag("confirm", false, i18n.T(i18n.ConfirmFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpProjectDelete)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/httpClient"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/region"
+)
+
+func regionCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("region").
+		Short(i18n.T(i18n.CmdDescRegion)).
+		HelpFlag(i18n.T(i18n.CmdHelpRegion)).
+		AddChildrenCmd(regionListCmd()).
+		AddChildrenCmd(regionUseCmd())
+}
+
+// retrieveRegions asks the region endpoint first, the cached list is used when it is unavailable
+func retrieveRegions(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) ([]region.RegionItem, error) {
+	regionRetriever := region.New(httpClient.New(ctx, httpClient.Config{HttpTimeout: time.Minute}))
+	return cmdData.CliStorage.RetrieveRegions(ctx, regionRetriever, cmdData.Params.GetString("regionUrl"), 0)
+}
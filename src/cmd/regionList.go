@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/region"
+	"github.com/zeropsio/zcli/src/uxBlock"
+)
+
+func regionListCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("list").
+		Short(i18n.T(i18n.CmdDescRegionList)).
+		StringFlag("regionUrl", constants.DefaultRegionUrl, i18n.T(i18n.RegionUrlFlag), cmdBuilder.HiddenFlag()).
+		HelpFlag(i18n.T(i18n.CmdHelpRegionList)).
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			regions, err := retrieveRegions(ctx, cmdData)
+			if err != nil {
+				return err
+			}
+
+			currentRegion := cmdData.CliStorage.Data().RegionData.Name
+			latencies := probeRegions(ctx, regions)
+
+			// TODO - janhajek translation
+			header := (&uxBlock.TableRow{}).AddStringCells("", "Name", "Default", "Address", "Latency")
+
+			body := &uxBlock.TableBody{}
+			for i, reg := range regions {
+				current := ""
+				if reg.Name == currentRegion {
+					current = "*"
+				}
+				isDefault := ""
+				if reg.IsDefault {
+					isDefault = "default"
+				}
+				body.AddStringsRow(current, reg.Name, isDefault, reg.Address, latencies[i])
+			}
+
+			cmdData.UxBlocks.Table(body, uxBlock.WithTableHeader(header))
+
+			return nil
+		})
+}
+
+// probeRegions measures the TCP connect time to all regions in parallel
+func probeRegions(ctx context.Context, regions []region.RegionItem) []string {
+	latencies := make([]string, len(regions))
+
+	var waitGroup sync.WaitGroup
+	for i, reg := range regions {
+		waitGroup.Add(1)
+		go func(i int, reg region.RegionItem) {
+			defer waitGroup.Done()
+
+			address := reg.Address
+			if _, _, err := net.SplitHostPort(address); err != nil {
+				address = net.JoinHostPort(address, "443")
+			}
+			result := nettools.ProbeTcp(ctx, address)
+			if result.Ok() {
+				latencies[i] = result.Rtt.Round(time.Millisecond).String()
+			} else {
+				latencies[i] = "-"
+			}
+		}(i, reg)
+	}
+	waitGroup.Wait()
+
+	return latencies
+}
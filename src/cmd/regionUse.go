@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/zeropsRestApiClient"
+)
+
+func regionUseCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("use").
+		Short(i18n.T(i18n.CmdDescRegionUse)).
+		StringFlag("regionUrl", constants.DefaultRegionUrl, i18n.T(i18n.RegionUrlFlag), cmdBuilder.HiddenFlag()).
+		HelpFlag(i18n.T(i18n.CmdHelpRegionUse)).
+		Arg("name").
+		GuestRunFunc(func(ctx context.Context, cmdData *cmdBuilder.GuestCmdData) error {
+			regions, err := retrieveRegions(ctx, cmdData)
+			if err != nil {
+				return err
+			}
+
+			reg, err := getLoginRegion(ctx, cmdData.UxBlocks, regions, cmdData.Args["name"][0])
+			if err != nil {
+				return err
+			}
+
+			// the token is kept, so it must be accepted by the new region
+			if token := cmdData.CliStorage.Data().Token; token != "" {
+				restApiClient := zeropsRestApiClient.NewAuthorizedClient(token, "https://"+reg.Address)
+				response, err := restApiClient.GetUserInfo(ctx)
+				if err == nil {
+					_, err = response.Output()
+				}
+				if err != nil {
+					return errors.WithMessage(err, i18n.T(i18n.RegionTokenInvalid, reg.Name))
+				}
+			}
+
+			previousRegion := cmdData.CliStorage.Data().RegionData
+			_, err = cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+				data.RegionData = reg
+				return data
+			})
+			if err != nil {
+				return err
+			}
+
+			cmdData.UxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.RegionSwitched, reg.Name)))
+
+			// tunnels of this login belong to projects of the previous region
+			if previousRegion.Name != "" && previousRegion.Name != reg.Name {
+				var projectNames []string
+				for _, tunnel := range loginVpnTunnels(cmdData.CliStorage) {
+					projectNames = append(projectNames, tunnel.ProjectName)
+				}
+				if len(projectNames) > 0 {
+					cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(
+						i18n.RegionVpnTunnels,
+						strings.Join(projectNames, ", "),
+						previousRegion.Name,
+					)))
+				}
+			}
+
+			return nil
+		})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/zeropsio/zcli/src/cliStorage"
 	"github.com/zeropsio/zcli/src/cmd/scope"
 	"github.com/zeropsio/zcli/src/cmdBuilder"
 	"github.com/zeropsio/zcli/src/constants"
@@ -24,8 +25,15 @@ func rootCmd() *cmdBuilder.Cmd {
 		Use("zcli").
 		SetHelpTemplate(getRootTemplate()).
 		SilenceError(true).
+		StringFlag(cmdBuilder.ContextFlagName, "", i18n.T(i18n.ContextFlag), cmdBuilder.PersistentFlag()).
 		AddChildrenCmd(loginCmd()).
+		AddChildrenCmd(logoutCmd()).
+		AddChildrenCmd(authCmd()).
+		AddChildrenCmd(contextCmd()).
+		AddChildrenCmd(regionCmd()).
+		AddChildrenCmd(configCmd()).
 		AddChildrenCmd(versionCmd()).
+		AddChildrenCmd(completionCmd()).
 		AddChildrenCmd(scopeCmd()).
 		AddChildrenCmd(projectCmd()).
 		AddChildrenCmd(serviceCmd()).
@@ -36,6 +44,7 @@ func rootCmd() *cmdBuilder.Cmd {
 			body := &uxBlock.TableBody{}
 
 			body.AddStringsRow(i18n.T(i18n.StatusInfoLoggedUser), "-")
+			body.AddStringsRow(i18n.T(i18n.StatusInfoContext), cmdData.CliStorage.ContextName())
 
 			guestInfoPart(body)
 
@@ -61,6 +70,8 @@ func rootCmd() *cmdBuilder.Cmd {
 			}
 
 			body.AddStringsRow(i18n.T(i18n.StatusInfoLoggedUser), loggedUser)
+			body.AddStringsRow(i18n.T(i18n.StatusInfoContext), cmdData.CliStorage.ContextName())
+			body.AddStringsRow(i18n.T(i18n.StatusInfoToken), tokenSourceText(cmdData.CliStorage))
 
 			guestInfoPart(body)
 
@@ -82,10 +93,20 @@ func rootCmd() *cmdBuilder.Cmd {
 				}
 			}
 
-			if isVpnUp(ctx, cmdData.UxBlocks, 1) {
-				body.AddStringsRow(i18n.T(i18n.StatusInfoVpnStatus), i18n.T(i18n.VpnCheckingConnectionIsActive))
-			} else {
-				body.AddStringsRow(i18n.T(i18n.StatusInfoVpnStatus), i18n.T(i18n.VpnCheckingConnectionIsNotActive))
+			vpnStatuses := getVpnTunnelStatuses(ctx, activeVpnTunnels(cmdData))
+			vpnStatus := i18n.T(i18n.VpnCheckingConnectionIsNotActive)
+			for _, status := range vpnStatuses {
+				if status.Connected {
+					vpnStatus = i18n.T(i18n.VpnCheckingConnectionIsActive)
+				}
+			}
+			body.AddStringsRow(i18n.T(i18n.StatusInfoVpnStatus), vpnStatus)
+
+			for _, status := range vpnStatuses {
+				body.AddStringsRow(
+					i18n.T(i18n.StatusInfoVpnTunnel, status.ProjectName),
+					fmt.Sprintf("%s [%s], %s, RTT %s", status.stateText(), status.InterfaceName, status.handshakeText(), status.rttText()),
+				)
 			}
 
 			cmdData.UxBlocks.Table(body)
@@ -97,6 +118,13 @@ func rootCmd() *cmdBuilder.Cmd {
 		})
 }
 
+func tokenSourceText(storageHandler *cliStorage.Handler) string {
+	if storageHandler.IsEnvLogin() {
+		return i18n.T(i18n.StatusInfoTokenFromEnv)
+	}
+	return i18n.T(i18n.StatusInfoTokenStored)
+}
+
 func guestInfoPart(tableBody *uxBlock.TableBody) {
 	cliDataFilePath, _, err := constants.CliDataFilePath()
 	if err != nil {
@@ -109,12 +137,6 @@ func guestInfoPart(tableBody *uxBlock.TableBody) {
 		logFilePath = err.Error()
 	}
 	tableBody.AddStringsRow(i18n.T(i18n.StatusInfoLogFilePath), logFilePath)
-
-	wgConfigFilePath, _, err := constants.WgConfigFilePath()
-	if err != nil {
-		wgConfigFilePath = err.Error()
-	}
-	tableBody.AddStringsRow(i18n.T(i18n.StatusInfoWgConfigFilePath), wgConfigFilePath)
 }
 
 func getRootTemplate() string {
@@ -149,7 +171,13 @@ func getRootTemplate() string {
 ` + styles.CobraSectionColor().SetString("Global Env Variables:").String() + `
   ` + styles.CobraItemNameColor().SetString(constants.CliLogFilePathEnvVar).String() + `     ` + i18n.T(i18n.CliLogFilePathEnvVar) + `
   ` + styles.CobraItemNameColor().SetString(constants.CliDataFilePathEnvVar).String() + `    ` + i18n.T(i18n.CliDataFilePathEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliConfigFilePathEnvVar).String() + `  ` + i18n.T(i18n.CliConfigFilePathEnvVar) + `
   ` + styles.CobraItemNameColor().SetString(constants.CliTerminalMode).String() + `     ` + i18n.T(i18n.CliTerminalModeEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliSecretStoreEnvVar).String() + `          ` + i18n.T(i18n.CliSecretStoreEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliSecretPassphraseEnvVar).String() + `     ` + i18n.T(i18n.CliSecretPassphraseEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliContextEnvVar).String() + `               ` + i18n.T(i18n.CliContextEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliTokenEnvVar).String() + `                 ` + i18n.T(i18n.CliTokenEnvVar) + `
+  ` + styles.CobraItemNameColor().SetString(constants.CliRegionEnvVar).String() + `                ` + i18n.T(i18n.CliRegionEnvVar) + `
 
 Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
 `
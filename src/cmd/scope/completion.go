@@ -0,0 +1,80 @@
+package scope
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity/repository"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+// ProjectCompletion offers ids of all projects of the logged user, project names are the descriptions
+func ProjectCompletion(ctx context.Context, cmdData *cmdBuilder.CompletionCmdData) ([]string, error) {
+	if cmdData.RestApiClient == nil {
+		return nil, nil
+	}
+	return cmdData.Cached("projects", func() ([]string, error) {
+		projects, err := repository.GetAllProjects(ctx, cmdData.RestApiClient)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(projects))
+		for _, project := range projects {
+			values = append(values, project.ID.Native()+"\t"+project.Name.Native())
+		}
+		return values, nil
+	})
+}
+
+// ServiceNameCompletion offers names of services of the selected project, service ids are the descriptions
+func ServiceNameCompletion(ctx context.Context, cmdData *cmdBuilder.CompletionCmdData) ([]string, error) {
+	return serviceCompletion(ctx, cmdData, false)
+}
+
+// ServiceIdCompletion offers ids of services of the selected project, service names are the descriptions
+func ServiceIdCompletion(ctx context.Context, cmdData *cmdBuilder.CompletionCmdData) ([]string, error) {
+	return serviceCompletion(ctx, cmdData, true)
+}
+
+// serviceCompletion needs the project from the --projectId flag or the project scope, there is no interactive selector
+func serviceCompletion(ctx context.Context, cmdData *cmdBuilder.CompletionCmdData, byId bool) ([]string, error) {
+	if cmdData.RestApiClient == nil {
+		return nil, nil
+	}
+
+	projectId := uuid.ProjectId(cmdData.Params.GetString(ProjectArgName))
+	if projectId == "" {
+		projectId, _ = cmdData.CliStorage.Data().ScopeProjectId.Get()
+	}
+	if projectId == "" {
+		return nil, nil
+	}
+
+	services, err := cmdData.Cached("services/"+projectId.Native(), func() ([]string, error) {
+		project, err := repository.GetProjectById(ctx, cmdData.RestApiClient, projectId)
+		if err != nil {
+			return nil, err
+		}
+		services, err := repository.GetNonSystemServicesByProject(ctx, cmdData.RestApiClient, *project)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(services))
+		for _, service := range services {
+			values = append(values, service.ID.Native()+"\t"+service.Name.Native())
+		}
+		return values, nil
+	})
+	if err != nil || byId {
+		return services, err
+	}
+
+	// both completions share the cached values, names are swapped with ids here
+	values := make([]string, 0, len(services))
+	for _, service := range services {
+		id, name, _ := strings.Cut(service, "\t")
+		values = append(values, name+"\t"+id)
+	}
+	return values, nil
+}
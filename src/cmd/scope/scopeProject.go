@@ -26,7 +26,7 @@ func (p *project) GetParent() cmdBuilder.ScopeLevel {
 }
 
 func (p *project) AddCommandFlags(cmd *cmdBuilder.Cmd) {
-	cmd.StringFlag(ProjectArgName, "", i18n.T(i18n.ProjectIdFlag))
+	cmd.StringFlag(ProjectArgName, "", i18n.T(i18n.ProjectIdFlag), cmdBuilder.FlagCompletion(ProjectCompletion))
 }
 
 func (p *project) LoadSelectedScope(ctx context.Context, cmd *cmdBuilder.Cmd, cmdData *cmdBuilder.LoggedUserCmdData) error {
@@ -26,7 +26,7 @@ const ServiceArgName = "serviceIdOrName"
 const serviceFlagName = "serviceId"
 
 func (s *service) AddCommandFlags(cmd *cmdBuilder.Cmd) {
-	cmd.StringFlag(serviceFlagName, "", i18n.T(i18n.ServiceIdFlag))
+	cmd.StringFlag(serviceFlagName, "", i18n.T(i18n.ServiceIdFlag), cmdBuilder.FlagCompletion(ServiceIdCompletion))
 }
 
 func (s *service) LoadSelectedScope(ctx context.Context, _ *cmdBuilder.Cmd, cmdData *cmdBuilder.LoggedUserCmdData) error {
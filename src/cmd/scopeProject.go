@@ -20,7 +20,7 @@ func scopeProjectCmd() *cmdBuilder.Cmd {
 	return cmdBuilder.NewCmd().
 		Use("project").
 		Short(i18n.T(i18n.CmdDescScopeProject)).
-		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
 		HelpFlag(i18n.T(i18n.CmdHelpScopeProject)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
 			projectId, projectSet := cmdData.CliStorage.Data().ScopeProjectId.Get()
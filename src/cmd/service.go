@@ -17,5 +17,6 @@ func serviceCmd() *cmdBuilder.Cmd {
 		AddChildrenCmd(serviceStopCmd()).
 		AddChildrenCmd(servicePushCmd()).
 		AddChildrenCmd(serviceEnableSubdomainCmd()).
-		AddChildrenCmd(serviceDeployCmd())
+		AddChildrenCmd(serviceDeployCmd()).
+		AddChildrenCmd(servicePortForwardCmd())
 }
@@ -17,7 +17,7 @@ func serviceDeleteCmd() *cmdBuilder.Cmd {
 		Use("delete").
 		Short(i18n.T(i18n.CmdDescServiceDelete)).
 		ScopeLevel(scope.Service).
-		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ServiceNameCompletion)).
 		BoolFlag("confirm", false, i18n.T(i18n.ConfirmFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceDelete)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
@@ -30,7 +30,7 @@ func serviceDeployCmd() *cmdBuilder.Cmd {
 		StringFlag("archiveFilePath", "", i18n.T(i18n.BuildArchiveFilePath)).
 		StringFlag("versionName", "", i18n.T(i18n.BuildVersionName)).
 		StringFlag("zeropsYamlPath", "", i18n.T(i18n.ZeropsYamlLocation)).
-		StringFlag("setup", "", i18n.T(i18n.ZeropsYamlSetup)).
+		StringFlag("setup", "", i18n.T(i18n.ZeropsYamlSetup), cmdBuilder.FlagCompletion(zeropsYamlSetupCompletion)).
 		BoolFlag("deployGitFolder", false, i18n.T(i18n.ZeropsYamlLocation)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceDeploy)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
@@ -16,7 +16,7 @@ func serviceEnableSubdomainCmd() *cmdBuilder.Cmd {
 		Use("enable-subdomain").
 		Short(i18n.T(i18n.CmdDescServiceEnableSubdomain)).
 		ScopeLevel(scope.Service).
-		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ServiceNameCompletion)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceEnableSubdomain)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
 			enableSubdomainResponse, err := cmdData.RestApiClient.PutServiceStackEnableSubdomainAccess(
@@ -14,7 +14,7 @@ func serviceListCmd() *cmdBuilder.Cmd {
 		Use("list").
 		Short(i18n.T(i18n.CmdDescServiceList)).
 		ScopeLevel(scope.Project).
-		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceList)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
 			err := uxHelpers.PrintServiceList(ctx, cmdData.UxBlocks, cmdData.RestApiClient, *cmdData.Project)
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/zeropsio/zcli/src/cmd/scope"
@@ -21,10 +22,20 @@ func serviceLogCmd() *cmdBuilder.Cmd {
 		ScopeLevel(scope.Service).
 		IntFlag("limit", 100, i18n.T(i18n.LogLimitFlag)).
 		StringFlag("minimumSeverity", "", i18n.T(i18n.LogMinSeverityFlag)).
-		StringFlag("messageType", "APPLICATION", i18n.T(i18n.LogMsgTypeFlag)).
-		StringFlag("format", "FULL", i18n.T(i18n.LogFormatFlag)).
+		EnumFlag("messageType", serviceLogs.APPLICATION, serviceLogs.MessageTypes, i18n.T(i18n.LogMsgTypeFlag)).
+		EnumFlag("format", "", serviceLogs.Formats, i18n.T(i18n.LogFormatFlag)).
 		StringFlag("formatTemplate", "", i18n.T(i18n.LogFormatTemplateFlag)).
 		BoolFlag("follow", false, i18n.T(i18n.LogFollowFlag)).
+		DurationFlag("since", 0, i18n.T(i18n.LogSinceFlag)).
+		StringFlag("output", "", i18n.T(i18n.LogOutputFlag)).
+		IntFlag("rotateSizeMb", 0, i18n.T(i18n.LogRotateSizeFlag)).
+		DurationFlag("rotateInterval", 0, i18n.T(i18n.LogRotateIntervalFlag)).
+		StringFlag("on-match", "", i18n.T(i18n.LogOnMatchFlag)).
+		StringFlag("exec", "", i18n.T(i18n.LogAlertExecFlag)).
+		StringFlag("webhook", "", i18n.T(i18n.LogAlertWebhookFlag)).
+		StringFlag("alertSeverity", "", i18n.T(i18n.LogAlertSeverityFlag)).
+		DurationFlag("alertRateLimit", 10*time.Second, i18n.T(i18n.LogAlertRateLimitFlag)).
+		DurationFlag("alertDedup", 5*time.Minute, i18n.T(i18n.LogAlertDedupFlag)).
 		BoolFlag("showBuildLogs", false, i18n.T(i18n.LogShowBuildFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceLog)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
@@ -65,6 +76,18 @@ func serviceLogCmd() *cmdBuilder.Cmd {
 				Format:         cmdData.Params.GetString("format"),
 				FormatTemplate: cmdData.Params.GetString("formatTemplate"),
 				Follow:         cmdData.Params.GetBool("follow"),
+				Since:          cmdData.Params.GetDuration("since"),
+				Output:         cmdData.Params.GetString("output"),
+				RotateSizeMb:   cmdData.Params.GetInt("rotateSizeMb"),
+				RotateInterval: cmdData.Params.GetDuration("rotateInterval"),
+				IsTerminal:     cmdData.UxBlocks.IsTerminal(),
+
+				OnMatch:          cmdData.Params.GetString("on-match"),
+				AlertExec:        cmdData.Params.GetString("exec"),
+				AlertWebhook:     cmdData.Params.GetString("webhook"),
+				AlertSeverity:    cmdData.Params.GetString("alertSeverity"),
+				AlertRateLimit:   cmdData.Params.GetDuration("alertRateLimit"),
+				AlertDedupWindow: cmdData.Params.GetDuration("alertDedup"),
 				// TODO - janhajek better place?
 				Levels: serviceLogs.Levels{
 					{"EMERGENCY", "0"},
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cmd/scope"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/proxyServer"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const servicePortsArgName = "localPort:remotePort"
+
+func servicePortForwardCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("port-forward").
+		Short(i18n.T(i18n.CmdDescServicePortForward)).
+		ScopeLevel(scope.Service).
+		Arg(scope.ServiceArgName, cmdBuilder.ArgCompletion(scope.ServiceNameCompletion)).
+		Arg(servicePortsArgName, cmdBuilder.ArrayArg()).
+		HelpFlag(i18n.T(i18n.CmdHelpServicePortForward)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			uxBlocks := cmdData.UxBlocks
+
+			remoteHost := cmdData.Service.Name.String() + "." + wg.ZeropsDomain
+			forwards, err := parseServicePortForwards(remoteHost, cmdData.Args[servicePortsArgName])
+			if err != nil {
+				return err
+			}
+
+			dial, closeTunnel, err := getServiceDialer(ctx, cmdData)
+			if err != nil {
+				return err
+			}
+			defer closeTunnel()
+
+			listeners := make([]net.Listener, 0, len(forwards))
+			defer func() {
+				for _, listener := range listeners {
+					listener.Close()
+				}
+			}()
+			for _, forward := range forwards {
+				listener, err := net.Listen("tcp", forward.localAddress)
+				if err != nil {
+					return errors.Wrap(err, i18n.T(i18n.VpnProxyListenFailed))
+				}
+				listeners = append(listeners, listener)
+			}
+
+			proxy := proxyServer.New(proxyServer.Config{
+				Dial: dial,
+				OnError: func(err error) {
+					uxBlocks.LogDebug(err.Error())
+				},
+			})
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			var waitGroup sync.WaitGroup
+			errs := make(chan error, len(listeners))
+			for i, forward := range forwards {
+				listener, remoteAddress := listeners[i], forward.remoteAddress
+				waitGroup.Add(1)
+				go func() {
+					defer waitGroup.Done()
+					if err := proxy.Forward(ctx, listener, remoteAddress); err != nil {
+						errs <- err
+						cancel()
+					}
+				}()
+				uxBlocks.PrintInfo(styles.InfoWithValueLine(
+					i18n.T(i18n.VpnForwardListening),
+					fmt.Sprintf("%s -> %s", listener.Addr().String(), remoteAddress),
+				))
+			}
+			uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.ServicePortForwardRunning)))
+
+			<-ctx.Done()
+			waitGroup.Wait()
+			close(errs)
+
+			return <-errs
+		})
+}
+
+// getServiceDialer reuses the VPN tunnel of the project if it's connected, otherwise the tunnel is created
+// inside the zcli process only for the port forward
+func getServiceDialer(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) (proxyServer.DialFunc, func(), error) {
+	if tunnel, exists := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]; exists && wg.IsInterfaceUp(tunnel.InterfaceName) {
+		cmdData.UxBlocks.LogDebug(fmt.Sprintf("port-forward: using the VPN tunnel %s", tunnel.InterfaceName))
+		return tunnelDialer(tunnel.Gateway), func() {}, nil
+	}
+
+	privateKey, vpnSettings, err := registerVpnKey(ctx, cmdData, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	tunnel, err := wg.NewUserspaceTunnel(privateKey, vpnSettings, wg.DefaultMtu, func(format string, args ...any) {
+		cmdData.UxBlocks.LogDebug(fmt.Sprintf(format, args...))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tunnel.DialContext, func() { tunnel.Close() }, nil
+}
+
+// tunnelDialer resolves hosts by the project DNS server, so the port forward works even with --dns none
+func tunnelDialer(gateway string) proxyServer.DialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		var dialer net.Dialer
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || gateway == "" || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addresses, err := nettools.LookupHost(ctx, gateway, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range addresses {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
+
+// parseServicePortForwards parses localPort:remotePort items, a single port is used for both sides
+// and an empty local port picks a free one
+func parseServicePortForwards(remoteHost string, items []string) ([]portForward, error) {
+	forwards := make([]portForward, 0, len(items))
+	for _, item := range items {
+		localPort, remotePort, found := strings.Cut(item, ":")
+		if !found {
+			localPort, remotePort = item, item
+		}
+		if localPort == "" {
+			localPort = "0"
+		}
+		if (localPort != "0" && !isPort(localPort)) || !isPort(remotePort) {
+			return nil, errors.New(i18n.T(i18n.ServicePortForwardInvalid, item))
+		}
+
+		forwards = append(forwards, portForward{
+			localAddress:  net.JoinHostPort("127.0.0.1", localPort),
+			remoteAddress: net.JoinHostPort(remoteHost, remotePort),
+		})
+	}
+	return forwards, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
 	"github.com/zeropsio/zcli/src/entity"
 	"github.com/zeropsio/zcli/src/errorsx"
 	"github.com/zeropsio/zcli/src/httpClient"
@@ -20,6 +21,7 @@ import (
 	"github.com/zeropsio/zerops-go/dto/input/body"
 	"github.com/zeropsio/zerops-go/dto/output"
 	"github.com/zeropsio/zerops-go/types"
+	"gopkg.in/yaml.v3"
 )
 
 func createAppVersion(
@@ -94,7 +96,8 @@ func packageUpload(ctx context.Context, client *httpClient.Handler, uploadUrl st
 	return nil
 }
 
-func getValidConfigContent(uxBlocks uxBlock.UxBlocks, selectedWorkingDir string, selectedZeropsYamlPath string) ([]byte, error) {
+// getZeropsYamlPathsToCheck returns the selected zerops.yaml or both default names in the working dir
+func getZeropsYamlPathsToCheck(selectedWorkingDir string, selectedZeropsYamlPath string) ([]string, error) {
 	workingDir, err := filepath.Abs(selectedWorkingDir)
 	if err != nil {
 		return nil, err
@@ -111,6 +114,14 @@ func getValidConfigContent(uxBlocks uxBlock.UxBlocks, selectedWorkingDir string,
 		pathsToCheck = append(pathsToCheck, filepath.Join(workingDir, "zerops.yaml"))
 		pathsToCheck = append(pathsToCheck, filepath.Join(workingDir, "zerops.yml"))
 	}
+	return pathsToCheck, nil
+}
+
+func getValidConfigContent(uxBlocks uxBlock.UxBlocks, selectedWorkingDir string, selectedZeropsYamlPath string) ([]byte, error) {
+	pathsToCheck, err := getZeropsYamlPathsToCheck(selectedWorkingDir, selectedZeropsYamlPath)
+	if err != nil {
+		return nil, err
+	}
 
 	zeropsYamlPath, err := func() (string, error) {
 		for _, path := range pathsToCheck {
@@ -141,6 +152,40 @@ func getValidConfigContent(uxBlocks uxBlock.UxBlocks, selectedWorkingDir string,
 	return yamlContent, nil
 }
 
+// zeropsYamlSetupCompletion offers setup names of the local zerops.yaml, the file is found the same way as by push and deploy
+func zeropsYamlSetupCompletion(_ context.Context, cmdData *cmdBuilder.CompletionCmdData) ([]string, error) {
+	pathsToCheck, err := getZeropsYamlPathsToCheck(
+		cmdData.Params.GetString("workingDir"),
+		cmdData.Params.GetString("zeropsYamlPath"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range pathsToCheck {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var zeropsYaml struct {
+			Zerops []struct {
+				Setup string `yaml:"setup"`
+			} `yaml:"zerops"`
+		}
+		if err := yaml.Unmarshal(content, &zeropsYaml); err != nil {
+			return nil, err
+		}
+		setups := make([]string, 0, len(zeropsYaml.Zerops))
+		for _, item := range zeropsYaml.Zerops {
+			if item.Setup != "" {
+				setups = append(setups, item.Setup)
+			}
+		}
+		return setups, nil
+	}
+	return nil, nil
+}
+
 func validateZeropsYamlContent(
 	ctx context.Context,
 	restApiClient *zeropsRestApiClient.Handler,
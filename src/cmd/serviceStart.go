@@ -15,7 +15,7 @@ func serviceStartCmd() *cmdBuilder.Cmd {
 		Use("start").
 		Short(i18n.T(i18n.CmdDescServiceStart)).
 		ScopeLevel(scope.Service).
-		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg(), cmdBuilder.OptionalArgLabel("{serviceName | serviceId}")).
+		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg(), cmdBuilder.OptionalArgLabel("{serviceName | serviceId}"), cmdBuilder.ArgCompletion(scope.ServiceNameCompletion)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceStart)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
 			startServiceResponse, err := cmdData.RestApiClient.PutServiceStackStart(
@@ -16,7 +16,7 @@ func serviceStopCmd() *cmdBuilder.Cmd {
 		Use("stop").
 		Short(i18n.T(i18n.CmdDescServiceStop)).
 		ScopeLevel(scope.Service).
-		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ServiceArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ServiceNameCompletion)).
 		HelpFlag(i18n.T(i18n.CmdHelpServiceStop)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
 			stopServiceResponse, err := cmdData.RestApiClient.PutServiceStackStop(
@@ -11,5 +11,9 @@ func vpnCmd() *cmdBuilder.Cmd {
 		Short(i18n.T(i18n.CmdDescVpn)).
 		HelpFlag(i18n.T(i18n.CmdHelpVpn)).
 		AddChildrenCmd(vpnUpCmd()).
-		AddChildrenCmd(vpnDownCmd())
+		AddChildrenCmd(vpnDownCmd()).
+		AddChildrenCmd(vpnStatusCmd()).
+		AddChildrenCmd(vpnKeyCmd()).
+		AddChildrenCmd(vpnConfigCmd()).
+		AddChildrenCmd(vpnDoctorCmd())
 }
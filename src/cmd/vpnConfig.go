@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/zeropsio/zcli/src/cmd/scope"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/file"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const (
+	vpnConfigFormatWgQuick        = "wg-quick"
+	vpnConfigFormatNetworkManager = "networkmanager"
+	vpnConfigFormatJson           = "json"
+)
+
+func vpnConfigCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("config").
+		Short(i18n.T(i18n.CmdDescVpnConfig)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnConfig)).
+		AddChildrenCmd(vpnConfigExportCmd())
+}
+
+func vpnConfigExportCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("export").
+		Short(i18n.T(i18n.CmdDescVpnConfigExport)).
+		ScopeLevel(scope.Project).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
+		EnumFlag("format", vpnConfigFormatWgQuick, []string{vpnConfigFormatWgQuick, vpnConfigFormatNetworkManager, vpnConfigFormatJson}, i18n.T(i18n.VpnConfigFormatFlag)).
+		StringFlag("output", "", i18n.T(i18n.VpnConfigOutputFlag)).
+		EnumFlag("dns", string(wg.DnsModeNone), wg.DnsModeNames(), i18n.T(i18n.VpnConfigDnsFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnConfigExport)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			format := strings.ToLower(cmdData.Params.GetString("format"))
+			dnsMode, err := wg.ParseDnsMode(cmdData.Params.GetString("dns"))
+			if err != nil {
+				return err
+			}
+			if dnsMode == wg.DnsModeAuto {
+				if dnsMode, err = resolveVpnDnsMode(cmdData, dnsMode); err != nil {
+					return err
+				}
+			}
+
+			// the exported config belongs to another machine, its key is never stored locally
+			privateKey, err := wgtypes.GeneratePrivateKey()
+			if err != nil {
+				return err
+			}
+			vpnSettings, err := registerVpnPeer(ctx, cmdData, privateKey.PublicKey())
+			if err != nil {
+				return err
+			}
+			cmdData.UxBlocks.LogDebug(fmt.Sprintf("vpn config export: registered public key %s", privateKey.PublicKey()))
+
+			outputPath := cmdData.Params.GetString("output")
+			var w io.Writer = os.Stdout
+			if outputPath != "" && outputPath != "-" {
+				// the config contains the private key
+				f, err := file.Open(outputPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			interfaceName := wg.InterfaceName(cmdData.Project.ID)
+			switch format {
+			case vpnConfigFormatWgQuick:
+				err = wg.GenerateConfig(w, privateKey, vpnSettings, dnsMode)
+			default:
+				var config wg.ExportConfig
+				config, err = wg.NewExportConfig(interfaceName, privateKey, vpnSettings)
+				if err != nil {
+					return err
+				}
+				if format == vpnConfigFormatNetworkManager {
+					err = config.WriteNetworkManager(w)
+				} else {
+					encoder := json.NewEncoder(w)
+					encoder.SetIndent("", "  ")
+					err = encoder.Encode(config)
+				}
+			}
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			// nothing else may be printed into the config on stdout
+			if w != os.Stdout {
+				cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnConfigExported), outputPath))
+				cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnConfigPublicKey), privateKey.PublicKey().String()))
+			}
+
+			return nil
+		})
+}
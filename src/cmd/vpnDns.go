@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity/repository"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const vpnHostsLookupAttempts = 3
+
+func getVpnDnsMode(cmdData *cmdBuilder.LoggedUserCmdData) (wg.DnsMode, error) {
+	dnsMode, err := wg.ParseDnsMode(cmdData.Params.GetString("dns"))
+	if err != nil {
+		return "", err
+	}
+	return resolveVpnDnsMode(cmdData, dnsMode)
+}
+
+// storedVpnDnsMode returns the mode the project tunnel was connected with, e.g. to reconnect it with a new key
+func storedVpnDnsMode(cmdData *cmdBuilder.LoggedUserCmdData) (wg.DnsMode, error) {
+	if tunnel, exists := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]; exists && tunnel.DnsMode != "" {
+		return wg.DnsMode(tunnel.DnsMode), nil
+	}
+	return resolveVpnDnsMode(cmdData, wg.DnsModeAuto)
+}
+
+func resolveVpnDnsMode(cmdData *cmdBuilder.LoggedUserCmdData, dnsMode wg.DnsMode) (wg.DnsMode, error) {
+	resolved, stack, err := wg.ResolveDnsMode(dnsMode)
+	if err != nil {
+		return "", err
+	}
+	cmdData.UxBlocks.LogDebug(fmt.Sprintf("vpn dns: mode %s, resolver %s", resolved, stack))
+	if resolved == wg.DnsModeResolvconf {
+		cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnDnsResolvconfGlobal)))
+	}
+	return resolved, nil
+}
+
+// writeVpnHosts resolves project services by the project DNS server and writes them into the hosts file,
+// the tunnel has to be up already
+func writeVpnHosts(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, interfaceName, gateway string) error {
+	services, err := repository.GetNonSystemServicesByProject(ctx, cmdData.RestApiClient, *cmdData.Project)
+	if err != nil {
+		return err
+	}
+
+	hosts := []string{vpnCheckAddress}
+	for _, service := range services {
+		hosts = append(hosts, service.Name.String()+"."+wg.ZeropsDomain)
+	}
+
+	entries := make(map[string][]string, len(hosts))
+	for _, host := range hosts {
+		addresses, err := lookupVpnHost(ctx, gateway, host)
+		if err != nil {
+			cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnDnsHostsResolveFailed, host, err)))
+			continue
+		}
+		entries[host] = addresses
+	}
+
+	return wg.WriteHostsEntries(interfaceName, entries)
+}
+
+// lookupVpnHost retries the lookup, the first handshake of a new tunnel takes a moment
+func lookupVpnHost(ctx context.Context, gateway, host string) ([]string, error) {
+	var err error
+	for i := 0; i < vpnHostsLookupAttempts; i++ {
+		var addresses []string
+		lookupCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		addresses, err = nettools.LookupHost(lookupCtx, gateway, host)
+		cancel()
+		if err == nil {
+			return addresses, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	return nil, err
+}
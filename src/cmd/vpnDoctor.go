@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cmd/scope"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/cmdRunner"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/entity/repository"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+const vpnDoctorEndpointTimeout = 10 * time.Second
+
+type vpnDoctorCheck struct {
+	name   string
+	detail string
+	// problem is empty if the check passed
+	problem string
+	fix     string
+	// warning marks problems which don't prevent the VPN from working
+	warning bool
+}
+
+func vpnDoctorCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("doctor").
+		Short(i18n.T(i18n.CmdDescVpnDoctor)).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnDoctor)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			uxBlocks := cmdData.UxBlocks
+
+			// the project is optional, the interactive selector isn't used so that doctor works in scripts
+			projectId, hasProject := cmdData.CliStorage.Data().ScopeProjectId.Get()
+			if args, exists := cmdData.Args[scope.ProjectArgName]; exists {
+				projectId, hasProject = uuid.ProjectId(args[0]), true
+			}
+			interfaceName := wg.LegacyInterfaceName
+			if hasProject {
+				project, err := repository.GetProjectById(ctx, cmdData.RestApiClient, projectId)
+				if err != nil {
+					return err
+				}
+				cmdData.Project = project
+				interfaceName = vpnInterfaceName(cmdData.CliStorage, project.ID)
+				uxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.SelectedProject), project.Name.String()))
+			}
+
+			checks := []vpnDoctorCheck{
+				checkVpnWgInstallation(),
+				checkVpnPrivileges(),
+			}
+			checks = append(checks, checkVpnInterfaces(cmdData)...)
+			checks = append(checks, checkVpnResolver())
+			checks = append(checks, checkVpnConfigFiles(interfaceName)...)
+			if hasProject {
+				checks = append(checks, checkVpnEndpoint(ctx, cmdData, interfaceName))
+			} else {
+				uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnDoctorEndpointSkipped)))
+			}
+
+			failed := 0
+			for _, check := range checks {
+				switch {
+				case check.problem == "":
+					uxBlocks.PrintInfo(styles.SuccessLine(fmt.Sprintf("%s: %s", check.name, check.detail)))
+					continue
+				case check.warning:
+					uxBlocks.PrintWarning(styles.WarningLine(fmt.Sprintf("%s: %s", check.name, check.problem)))
+				default:
+					failed++
+					uxBlocks.PrintError(styles.ErrorLine(fmt.Sprintf("%s: %s", check.name, check.problem)))
+				}
+				if check.fix != "" {
+					uxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnDoctorFix), check.fix))
+				}
+			}
+
+			if failed > 0 {
+				return errors.New(i18n.T(i18n.VpnDoctorFailed, failed))
+			}
+			uxBlocks.PrintInfo(styles.SuccessLine(i18n.T(i18n.VpnDoctorOk)))
+
+			return nil
+		})
+}
+
+func checkVpnWgInstallation() vpnDoctorCheck {
+	check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorWireguard), detail: i18n.T(i18n.VpnDoctorInstalled)}
+	if err := wg.CheckWgInstallation(); err != nil {
+		check.problem = i18n.T(i18n.VpnDoctorNotInstalled)
+		check.fix = err.Error()
+	}
+	return check
+}
+
+func checkVpnPrivileges() vpnDoctorCheck {
+	check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorPrivileges), detail: i18n.T(i18n.VpnDoctorPrivileged)}
+	if !cmdRunner.IsPrivileged() {
+		check.problem = i18n.T(i18n.VpnDoctorNotPrivileged)
+		check.fix = i18n.T(i18n.VpnDoctorFixPrivileges)
+		if runtime.GOOS == "windows" {
+			check.fix = i18n.T(i18n.VpnDoctorFixPrivilegesWindows)
+		}
+	}
+	return check
+}
+
+// checkVpnInterfaces finds tunnels which clash with the ones zcli creates, wg-quick fails on them with "File exists"
+func checkVpnInterfaces(cmdData *cmdBuilder.LoggedUserCmdData) []vpnDoctorCheck {
+	tracked := make(map[string]bool)
+	for _, tunnel := range cmdData.CliStorage.Data().VpnTunnels {
+		tracked[tunnel.InterfaceName] = true
+	}
+
+	var checks []vpnDoctorCheck
+	for _, name := range wg.ActiveInterfaces() {
+		check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorInterface, name)}
+		switch {
+		case name == wg.LegacyInterfaceName:
+			check.problem = i18n.T(i18n.VpnDoctorLegacyInterface)
+			check.fix = "zcli vpn down --all"
+		case !tracked[name]:
+			check.problem = i18n.T(i18n.VpnDoctorUntrackedInterface)
+			check.fix = "wg-quick down " + name
+			check.warning = true
+		default:
+			check.detail = i18n.T(i18n.VpnDoctorTrackedInterface)
+		}
+		checks = append(checks, check)
+	}
+	if len(checks) == 0 {
+		checks = append(checks, vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorInterfaces), detail: i18n.T(i18n.VpnDoctorNoInterfaces)})
+	}
+	return checks
+}
+
+func checkVpnResolver() vpnDoctorCheck {
+	check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorResolver)}
+	dnsMode, stack, err := wg.ResolveDnsMode(wg.DnsModeAuto)
+	switch {
+	case err != nil:
+		check.problem = err.Error()
+	case dnsMode == wg.DnsModeHosts:
+		check.problem = i18n.T(i18n.VpnDoctorResolverHosts, stack)
+		check.fix = i18n.T(i18n.VpnDoctorFixResolver)
+		check.warning = true
+	default:
+		check.detail = fmt.Sprintf("%s (--dns %s)", stack, dnsMode)
+	}
+	return check
+}
+
+// checkVpnConfigFiles reports the location zcli writes the tunnel config to and configs readable by other users
+func checkVpnConfigFiles(interfaceName string) []vpnDoctorCheck {
+	var checks []vpnDoctorCheck
+	var problems []string
+	writable := ""
+	for _, candidate := range constants.WgConfigFilePathCandidates(interfaceName) {
+		if candidate.Path == "" {
+			continue
+		}
+		if candidate.Err != nil {
+			problems = append(problems, candidate.Err.Error())
+			continue
+		}
+		if writable == "" {
+			writable = candidate.Path
+		}
+
+		// the config contains the private key
+		if info, err := os.Stat(candidate.Path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+			checks = append(checks, vpnDoctorCheck{
+				name:    i18n.T(i18n.VpnDoctorConfigFile),
+				problem: i18n.T(i18n.VpnDoctorConfigFileMode, candidate.Path, info.Mode().Perm()),
+				fix:     "chmod 600 " + candidate.Path,
+				warning: true,
+			})
+		}
+	}
+
+	check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorConfigFile), detail: writable}
+	if writable == "" {
+		check.problem = i18n.T(i18n.VpnDoctorConfigFileNotWritable, strings.Join(problems, "; "))
+		check.fix = i18n.T(i18n.VpnDoctorFixConfigFile, constants.CliWgConfigPathEnvVar)
+	}
+	return append([]vpnDoctorCheck{check}, checks...)
+}
+
+// checkVpnEndpoint needs a handshake with the project endpoint, a connected tunnel is checked by its device,
+// otherwise a short lived tunnel is created inside the zcli process
+func checkVpnEndpoint(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, interfaceName string) vpnDoctorCheck {
+	check := vpnDoctorCheck{name: i18n.T(i18n.VpnDoctorEndpoint)}
+
+	if wg.IsInterfaceUp(interfaceName) {
+		status, err := wg.ReadDeviceStatus(interfaceName)
+		if err == nil {
+			check.detail = status.Endpoint
+			if !status.IsConnected() {
+				check.problem = i18n.T(i18n.VpnDoctorEndpointNoHandshake, status.Endpoint)
+				check.fix = i18n.T(i18n.VpnDoctorFixEndpoint)
+			}
+			return check
+		}
+		tunnel := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]
+		if result := nettools.ProbeDns(ctx, tunnel.Gateway, vpnCheckAddress); !result.Ok() {
+			check.problem = i18n.T(i18n.VpnDoctorEndpointNoHandshake, interfaceName)
+			check.fix = i18n.T(i18n.VpnDoctorFixEndpoint)
+		}
+		check.detail = interfaceName
+		return check
+	}
+
+	privateKey, vpnSettings, err := registerVpnKey(ctx, cmdData, 0)
+	if err != nil {
+		check.problem = err.Error()
+		return check
+	}
+	endpoint := string(vpnSettings.Project.Ipv4.SharedEndpoint)
+	check.detail = endpoint
+
+	tunnel, err := wg.NewUserspaceTunnel(privateKey, vpnSettings, wg.DefaultMtu, func(format string, args ...any) {
+		cmdData.UxBlocks.LogDebug(fmt.Sprintf(format, args...))
+	})
+	if err != nil {
+		check.problem = err.Error()
+		return check
+	}
+	defer tunnel.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, vpnDoctorEndpointTimeout)
+	defer cancel()
+	if _, err := tunnel.LookupHost(ctx, vpnCheckAddress); err != nil {
+		cmdData.UxBlocks.LogDebug(fmt.Sprintf("vpn doctor: %s", err))
+		check.problem = i18n.T(i18n.VpnDoctorEndpointNoHandshake, endpoint)
+		check.fix = i18n.T(i18n.VpnDoctorFixEndpoint)
+	}
+	return check
+}
+
+// withVpnDoctorHint points to vpn doctor on wg-quick errors which are caused by the local setup
+func withVpnDoctorHint(err error) error {
+	if errors.Is(err, cmdRunner.ErrIpAlreadySet) || errors.Is(err, cmdRunner.ErrOperationNotPermitted) || errors.Is(err, cmdRunner.ErrCannotFindDevice) {
+		return errors.Errorf("%s\n%s", err, i18n.T(i18n.VpnDoctorHint))
+	}
+	return err
+}
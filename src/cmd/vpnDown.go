@@ -3,52 +3,150 @@ package cmd
 import (
 	"context"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
 	"github.com/zeropsio/zcli/src/cmdBuilder"
 	"github.com/zeropsio/zcli/src/cmdRunner"
 	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/entity"
 	"github.com/zeropsio/zcli/src/file"
 	"github.com/zeropsio/zcli/src/i18n"
-	"github.com/zeropsio/zcli/src/uxBlock"
 	"github.com/zeropsio/zcli/src/uxBlock/styles"
 	"github.com/zeropsio/zcli/src/wg"
 )
 
+const vpnProjectArgName = "project"
+
 func vpnDownCmd() *cmdBuilder.Cmd {
 	return cmdBuilder.NewCmd().
 		Use("down").
 		Short(i18n.T(i18n.CmdDescVpnDown)).
+		Arg(vpnProjectArgName, cmdBuilder.OptionalArg()).
+		BoolFlag("all", false, i18n.T(i18n.VpnDownAllFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpVpnDown)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
-			return disconnectVpn(ctx, cmdData.UxBlocks)
+			tunnels, err := selectVpnTunnelsToDisconnect(cmdData)
+			if err != nil {
+				return err
+			}
+
+			for _, tunnel := range tunnels {
+				if err := disconnectVpn(ctx, cmdData, tunnel); err != nil {
+					return err
+				}
+			}
+
+			return nil
 		})
 }
 
-func disconnectVpn(ctx context.Context, uxBlocks uxBlock.UxBlocks) error {
-	err := wg.CheckWgInstallation()
-	if err != nil {
-		return err
+// selectVpnTunnelsToDisconnect picks tunnels by the project arg, the --all flag or the project scope.
+// Without any of them the only active tunnel is used.
+func selectVpnTunnelsToDisconnect(cmdData *cmdBuilder.LoggedUserCmdData) ([]entity.VpnTunnel, error) {
+	tunnels := activeVpnTunnels(cmdData)
+
+	if cmdData.Params.GetBool("all") {
+		if len(tunnels) == 0 {
+			return nil, errors.New(i18n.T(i18n.VpnNoActiveTunnel))
+		}
+		return tunnels, nil
 	}
 
-	filePath, fileMode, err := constants.WgConfigFilePath()
-	if err != nil {
-		return err
+	if project, exists := cmdData.Args[vpnProjectArgName]; exists {
+		for _, tunnel := range tunnels {
+			if string(tunnel.ProjectId) == project[0] || tunnel.ProjectName == project[0] {
+				return []entity.VpnTunnel{tunnel}, nil
+			}
+		}
+		return nil, errors.New(i18n.T(i18n.VpnTunnelNotFound, project[0]))
 	}
 
-	// create empty file if not exists, only thing wg-quick needs is a proper file name
-	f, err := file.Open(filePath, os.O_RDWR|os.O_CREATE, fileMode)
-	if err != nil {
+	if projectId, filled := cmdData.CliStorage.Data().ScopeProjectId.Get(); filled {
+		for _, tunnel := range tunnels {
+			if tunnel.ProjectId == projectId {
+				return []entity.VpnTunnel{tunnel}, nil
+			}
+		}
+	}
+
+	switch len(tunnels) {
+	case 0:
+		return nil, errors.New(i18n.T(i18n.VpnNoActiveTunnel))
+	case 1:
+		return tunnels, nil
+	default:
+		names := make([]string, 0, len(tunnels))
+		for _, tunnel := range tunnels {
+			names = append(names, tunnel.ProjectName)
+		}
+		return nil, errors.New(i18n.T(i18n.VpnMultipleTunnels, strings.Join(names, ", ")))
+	}
+}
+
+// storedVpnTunnels returns tunnels connected by zcli sorted by the project name
+func storedVpnTunnels(storageHandler *cliStorage.Handler) []entity.VpnTunnel {
+	tunnels := make([]entity.VpnTunnel, 0, len(storageHandler.Data().VpnTunnels))
+	for _, tunnel := range storageHandler.Data().VpnTunnels {
+		tunnels = append(tunnels, tunnel)
+	}
+	sort.Slice(tunnels, func(i, j int) bool {
+		return tunnels[i].ProjectName < tunnels[j].ProjectName
+	})
+	return tunnels
+}
+
+func disconnectVpn(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, tunnel entity.VpnTunnel) error {
+	// a watcher would bring the tunnel up again
+	if tunnel.WatcherPid != 0 {
+		if err := stopVpnWatcher(tunnel.WatcherPid); err != nil {
+			return err
+		}
+	}
+
+	// the interface could have been removed outside of zcli, e.g. by a reboot
+	if wg.IsInterfaceUp(tunnel.InterfaceName) {
+		err := wg.CheckWgInstallation()
+		if err != nil {
+			return err
+		}
+
+		filePath, fileMode, err := constants.WgConfigFilePath(tunnel.InterfaceName)
+		if err != nil {
+			return err
+		}
+
+		// create empty file if not exists, only thing wg-quick needs is a proper file name
+		f, err := file.Open(filePath, os.O_RDWR|os.O_CREATE, fileMode)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		c := wg.DownCmd(ctx, filePath)
+		_, err = cmdRunner.Run(c)
+		if err != nil {
+			return withVpnDoctorHint(err)
+		}
+	}
+
+	// hosts entries outlive the interface, they are removed even if the tunnel is already gone
+	if err := wg.RemoveHostsEntries(tunnel.InterfaceName); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	c := wg.DownCmd(ctx, filePath)
-	_, err = cmdRunner.Run(c)
+	_, err := cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+		delete(data.VpnTunnels, tunnel.ProjectId)
+		return data
+	})
 	if err != nil {
 		return err
 	}
 
-	uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnDown)))
+	cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnDown), tunnel.ProjectName))
 
 	return nil
 }
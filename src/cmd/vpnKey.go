@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zerops-go/dto/input/body"
+	"github.com/zeropsio/zerops-go/dto/input/path"
+	"github.com/zeropsio/zerops-go/dto/output"
+	"github.com/zeropsio/zerops-go/types"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+func vpnKeyCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("key").
+		Short(i18n.T(i18n.CmdDescVpnKey)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnKey)).
+		AddChildrenCmd(vpnKeyListCmd()).
+		AddChildrenCmd(vpnKeyRotateCmd()).
+		AddChildrenCmd(vpnKeyDeleteCmd())
+}
+
+func vpnKeyListCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("list").
+		Short(i18n.T(i18n.CmdDescVpnKeyList)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnKeyList)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			vpnKeys := storedVpnKeys(cmdData.CliStorage)
+			if len(vpnKeys) == 0 {
+				cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnKeyNoKeys)))
+				return nil
+			}
+
+			// TODO - janhajek translation
+			header := (&uxBlock.TableRow{}).AddStringCells("Project", "Project ID", "Age", "Created", "Public key")
+
+			body := &uxBlock.TableBody{}
+			for _, vpnKey := range vpnKeys {
+				publicKey := i18n.T(i18n.VpnKeyCorrupted)
+				if privateKey, err := wgtypes.ParseKey(vpnKey.Key); err == nil {
+					publicKey = privateKey.PublicKey().String()
+				}
+
+				// keys stored by older versions have no project name
+				projectName := vpnKey.ProjectName
+				if projectName == "" {
+					projectName = "-"
+				}
+
+				body.AddStringsRow(
+					projectName,
+					string(vpnKey.ProjectId),
+					time.Since(vpnKey.CreatedAt).Round(time.Minute).String(),
+					vpnKey.CreatedAt.Local().Format(time.DateTime),
+					publicKey,
+				)
+			}
+
+			cmdData.UxBlocks.Table(body, uxBlock.WithTableHeader(header))
+
+			return nil
+		})
+}
+
+// storedVpnKeys returns keys sorted by the project name
+func storedVpnKeys(storageHandler *cliStorage.Handler) []entity.VpnKey {
+	vpnKeys := make([]entity.VpnKey, 0, len(storageHandler.Data().VpnKeys))
+	for _, vpnKey := range storageHandler.Data().VpnKeys {
+		vpnKeys = append(vpnKeys, vpnKey)
+	}
+	sort.Slice(vpnKeys, func(i, j int) bool {
+		return vpnKeys[i].ProjectName < vpnKeys[j].ProjectName
+	})
+	return vpnKeys
+}
+
+// getMaxVpnKeyAge returns zero if keys should never be rotated automatically
+func getMaxVpnKeyAge(cmdData *cmdBuilder.LoggedUserCmdData) (time.Duration, error) {
+	maxKeyAge := cmdData.Params.GetDuration("maxKeyAge")
+	if maxKeyAge < 0 {
+		return 0, errors.New(i18n.T(i18n.VpnMaxKeyAgeInvalid))
+	}
+	return maxKeyAge, nil
+}
+
+// registerVpnKey sends the public key to the project and stores the private key for the next connection.
+// A key older than maxKeyAge is replaced by a new one and removed from the project.
+func registerVpnKey(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	maxKeyAge time.Duration,
+) (wgtypes.Key, output.ProjectVpnItem, error) {
+	privateKey, expiredKey, err := getOrCreatePrivateVpnKey(cmdData, maxKeyAge)
+	if err != nil {
+		return wgtypes.Key{}, output.ProjectVpnItem{}, err
+	}
+
+	vpnSettings, err := postVpnKey(ctx, cmdData, privateKey)
+	if err != nil {
+		return wgtypes.Key{}, output.ProjectVpnItem{}, err
+	}
+
+	if expiredKey != nil {
+		removeReplacedVpnKey(ctx, cmdData, cmdData.Project.ID, *expiredKey)
+	}
+
+	return privateKey, vpnSettings, nil
+}
+
+// postVpnKey registers the public key in the project, the creation time is kept if the key didn't change
+func postVpnKey(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	privateKey wgtypes.Key,
+) (output.ProjectVpnItem, error) {
+	vpnSettings, err := registerVpnPeer(ctx, cmdData, privateKey.PublicKey())
+	if err != nil {
+		return output.ProjectVpnItem{}, err
+	}
+
+	_, err = cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+		if data.VpnKeys == nil {
+			data.VpnKeys = make(map[uuid.ProjectId]entity.VpnKey)
+		}
+		createdAt := time.Now()
+		if stored, exists := data.VpnKeys[cmdData.Project.ID]; exists && stored.Key == privateKey.String() && !stored.CreatedAt.IsZero() {
+			createdAt = stored.CreatedAt
+		}
+		data.VpnKeys[cmdData.Project.ID] = entity.VpnKey{
+			ProjectId:   cmdData.Project.ID,
+			ProjectName: cmdData.Project.Name.String(),
+			Key:         privateKey.String(),
+			CreatedAt:   createdAt,
+		}
+
+		return data
+	})
+	if err != nil {
+		return output.ProjectVpnItem{}, err
+	}
+
+	return vpnSettings, nil
+}
+
+// registerVpnPeer adds the public key as a peer of the project and returns the settings of the tunnel
+func registerVpnPeer(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	publicKey wgtypes.Key,
+) (output.ProjectVpnItem, error) {
+	postProjectResponse, err := cmdData.RestApiClient.PostProjectVpn(
+		ctx,
+		path.ProjectId{Id: cmdData.Project.ID},
+		body.PostProjectVpn{PublicKey: types.String(publicKey.String())},
+	)
+	if err != nil {
+		return output.ProjectVpnItem{}, err
+	}
+
+	return postProjectResponse.Output()
+}
+
+// deleteVpnKey removes the peer of the key from the project
+func deleteVpnKey(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, projectId uuid.ProjectId, privateKey wgtypes.Key) error {
+	response, err := cmdData.RestApiClient.DeleteProjectVpn(
+		ctx,
+		path.ProjectId{Id: projectId},
+		body.PostProjectVpn{PublicKey: types.String(privateKey.PublicKey().String())},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = response.Output()
+	return err
+}
+
+// removeReplacedVpnKey only warns on failure, the new key is already registered and the old one is not used anymore
+func removeReplacedVpnKey(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, projectId uuid.ProjectId, privateKey wgtypes.Key) {
+	if err := deleteVpnKey(ctx, cmdData, projectId, privateKey); err != nil {
+		cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnKeyDeregisterFailed, err)))
+	}
+}
+
+// getOrCreatePrivateVpnKey returns the stored key of the project or a new one.
+// The second returned key is the stored key which expired and has to be removed from the project.
+func getOrCreatePrivateVpnKey(cmdData *cmdBuilder.LoggedUserCmdData, maxKeyAge time.Duration) (wgtypes.Key, *wgtypes.Key, error) {
+	projectId := cmdData.Project.ID
+
+	var expiredKey *wgtypes.Key
+	if vpnKey, exists := cmdData.VpnKeys[projectId]; exists {
+		wgKey, err := wgtypes.ParseKey(vpnKey.Key)
+		if err == nil {
+			if maxKeyAge == 0 || time.Since(vpnKey.CreatedAt) < maxKeyAge {
+				return wgKey, nil, nil
+			}
+			expiredKey = &wgKey
+			cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnKeyExpired, maxKeyAge)))
+		} else {
+			cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnPrivateKeyCorrupted)))
+		}
+	}
+
+	vpnKey, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return wgtypes.Key{}, nil, err
+	}
+
+	cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnPrivateKeyCreated)))
+
+	return vpnKey, expiredKey, nil
+}
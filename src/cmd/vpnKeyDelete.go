@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zcli/src/errorsx"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/uxHelpers"
+	"github.com/zeropsio/zcli/src/wg"
+	"github.com/zeropsio/zerops-go/errorCode"
+)
+
+func vpnKeyDeleteCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("delete").
+		Short(i18n.T(i18n.CmdDescVpnKeyDelete)).
+		Arg(vpnProjectArgName, cmdBuilder.OptionalArg()).
+		BoolFlag("all", false, i18n.T(i18n.VpnKeyDeleteAllFlag)).
+		BoolFlag("confirm", false, i18n.T(i18n.ConfirmFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnKeyDelete)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			vpnKeys, err := selectVpnKeysToDelete(cmdData)
+			if err != nil {
+				return err
+			}
+
+			if !cmdData.Params.GetBool("confirm") {
+				confirmed, err := uxHelpers.YesNoPrompt(
+					ctx,
+					cmdData.UxBlocks,
+					i18n.T(i18n.VpnKeyDeleteConfirm, len(vpnKeys)),
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					return errors.New(i18n.T(i18n.DestructiveOperationConfirmationFailed))
+				}
+			}
+
+			for _, vpnKey := range vpnKeys {
+				if err := deleteStoredVpnKey(ctx, cmdData, vpnKey); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+}
+
+func selectVpnKeysToDelete(cmdData *cmdBuilder.LoggedUserCmdData) ([]entity.VpnKey, error) {
+	vpnKeys := storedVpnKeys(cmdData.CliStorage)
+	if len(vpnKeys) == 0 {
+		return nil, errors.New(i18n.T(i18n.VpnKeyNoKeys))
+	}
+
+	if cmdData.Params.GetBool("all") {
+		return vpnKeys, nil
+	}
+
+	project, exists := cmdData.Args[vpnProjectArgName]
+	if !exists {
+		return nil, errors.New(i18n.T(i18n.VpnKeyDeleteProjectMissing))
+	}
+	for _, vpnKey := range vpnKeys {
+		if string(vpnKey.ProjectId) == project[0] || vpnKey.ProjectName == project[0] {
+			return []entity.VpnKey{vpnKey}, nil
+		}
+	}
+	return nil, errors.New(i18n.T(i18n.VpnKeyNotFound, project[0]))
+}
+
+// deleteStoredVpnKey disconnects the tunnel using the key, removes its peer from the project and forgets it.
+// A corrupted key can't be removed from the project, it is only forgotten.
+func deleteStoredVpnKey(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, vpnKey entity.VpnKey) error {
+	if tunnel, exists := cmdData.CliStorage.Data().VpnTunnels[vpnKey.ProjectId]; exists && wg.IsInterfaceUp(tunnel.InterfaceName) {
+		if err := disconnectVpn(ctx, cmdData, tunnel); err != nil {
+			return err
+		}
+	}
+
+	if privateKey, err := wgtypes.ParseKey(vpnKey.Key); err == nil {
+		err := deleteVpnKey(ctx, cmdData, vpnKey.ProjectId, privateKey)
+		// the peer or the whole project could have been removed already
+		if err != nil &&
+			!errorsx.Is(err, errorsx.ErrorCode(errorCode.VpnPublicKeyNotFound)) &&
+			!errorsx.Is(err, errorsx.ErrorCode(errorCode.ProjectNotFound)) {
+			return err
+		}
+	}
+
+	_, err := cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+		delete(data.VpnKeys, vpnKey.ProjectId)
+		return data
+	})
+	if err != nil {
+		return err
+	}
+
+	cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnKeyDeleted), vpnKey.ProjectName))
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/zeropsio/zcli/src/cmd/scope"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+func vpnKeyRotateCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("rotate").
+		Short(i18n.T(i18n.CmdDescVpnKeyRotate)).
+		ScopeLevel(scope.Project).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnKeyRotate)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			var previousKey *wgtypes.Key
+			if vpnKey, exists := cmdData.VpnKeys[cmdData.Project.ID]; exists {
+				if key, err := wgtypes.ParseKey(vpnKey.Key); err == nil {
+					previousKey = &key
+				}
+			}
+
+			privateKey, err := wgtypes.GeneratePrivateKey()
+			if err != nil {
+				return err
+			}
+
+			vpnSettings, err := postVpnKey(ctx, cmdData, privateKey)
+			if err != nil {
+				return err
+			}
+			cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnKeyRotated), privateKey.PublicKey().String()))
+
+			// the running tunnel still uses the previous key, it stops working once the key is removed
+			tunnel, exists := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]
+			if !exists {
+				tunnel = entity.VpnTunnel{
+					ProjectId:     cmdData.Project.ID,
+					ProjectName:   cmdData.Project.Name.String(),
+					InterfaceName: vpnInterfaceName(cmdData.CliStorage, cmdData.Project.ID),
+				}
+			}
+			if wg.IsInterfaceUp(tunnel.InterfaceName) {
+				dnsMode, err := storedVpnDnsMode(cmdData)
+				if err != nil {
+					return err
+				}
+				// the watcher is stopped with the tunnel, otherwise it would bring up the tunnel with the previous key
+				if err := disconnectVpn(ctx, cmdData, tunnel); err != nil {
+					return err
+				}
+				if err := connectVpn(ctx, cmdData, tunnel.InterfaceName, dnsMode, privateKey, vpnSettings); err != nil {
+					return err
+				}
+				cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnUp)))
+
+				if tunnel.WatcherPid != 0 {
+					watchInterval := tunnel.WatchInterval
+					if watchInterval <= 0 {
+						watchInterval = defaultVpnWatchInterval
+					}
+					checkTargets := tunnel.CheckTargets
+					if checkTargets == "" {
+						checkTargets = defaultVpnCheckTargets
+					}
+					if err := startVpnWatcher(cmdData, tunnel.InterfaceName, watchInterval, checkTargets); err != nil {
+						return err
+					}
+				}
+			}
+
+			if previousKey != nil {
+				removeReplacedVpnKey(ctx, cmdData, cmdData.Project.ID, *previousKey)
+			}
+
+			return nil
+		})
+}
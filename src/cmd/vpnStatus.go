@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/entity"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/uxBlock"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const (
+	vpnStatusFormatTable = "table"
+	vpnStatusFormatJson  = "json"
+)
+
+type vpnTunnelStatus struct {
+	ProjectId      string     `json:"projectId,omitempty"`
+	ProjectName    string     `json:"projectName"`
+	InterfaceName  string     `json:"interface"`
+	ConfigFilePath string     `json:"configFilePath,omitempty"`
+	InterfaceUp    bool       `json:"interfaceUp"`
+	Connected      bool       `json:"connected"`
+	Endpoint       string     `json:"endpoint,omitempty"`
+	LastHandshake  *time.Time `json:"lastHandshake,omitempty"`
+	ReceiveBytes   int64      `json:"rxBytes"`
+	TransmitBytes  int64      `json:"txBytes"`
+	AllowedIps     []string   `json:"allowedIps,omitempty"`
+	DeviceError    string     `json:"deviceError,omitempty"`
+	Resolver       string     `json:"resolver"`
+	ResolverError  string     `json:"resolverError,omitempty"`
+	RttMs          float64    `json:"rttMs,omitempty"`
+	RttProbe       string     `json:"rttProbe,omitempty"`
+	RttError       string     `json:"rttError,omitempty"`
+}
+
+func vpnStatusCmd() *cmdBuilder.Cmd {
+	return cmdBuilder.NewCmd().
+		Use("status").
+		Short(i18n.T(i18n.CmdDescVpnStatus)).
+		EnumFlag("format", vpnStatusFormatTable, []string{vpnStatusFormatTable, vpnStatusFormatJson}, i18n.T(i18n.VpnStatusFormatFlag)).
+		HelpFlag(i18n.T(i18n.CmdHelpVpnStatus)).
+		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
+			format := strings.ToLower(cmdData.Params.GetString("format"))
+
+			statuses := getVpnTunnelStatuses(ctx, activeVpnTunnels(cmdData))
+
+			if format == vpnStatusFormatJson {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(statuses)
+			}
+
+			if len(statuses) == 0 {
+				cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnNoActiveTunnel)))
+				return nil
+			}
+
+			// TODO - janhajek translation
+			header := (&uxBlock.TableRow{}).AddStringCells(
+				"Project", "Interface", "Status", "Endpoint", "Handshake", "Rx / Tx", "Allowed IPs", "DNS", "RTT",
+			)
+
+			body := &uxBlock.TableBody{}
+			for _, status := range statuses {
+				body.AddStringsRow(
+					status.ProjectName,
+					status.InterfaceName,
+					status.stateText(),
+					status.Endpoint,
+					status.handshakeText(),
+					fmt.Sprintf("%s / %s", formatBytes(status.ReceiveBytes), formatBytes(status.TransmitBytes)),
+					strings.Join(status.AllowedIps, ", "),
+					status.Resolver,
+					status.rttText(),
+				)
+			}
+
+			cmdData.UxBlocks.Table(body, uxBlock.WithTableHeader(header))
+
+			for _, status := range statuses {
+				if status.DeviceError != "" {
+					cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnStatusDeviceUnreadable, status.InterfaceName, status.DeviceError)))
+				}
+			}
+
+			return nil
+		})
+}
+
+// activeVpnTunnels returns the stored tunnels and the single tunnel of older zcli versions if it is up
+func activeVpnTunnels(cmdData *cmdBuilder.LoggedUserCmdData) []entity.VpnTunnel {
+	tunnels := storedVpnTunnels(cmdData.CliStorage)
+	if wg.IsInterfaceUp(wg.LegacyInterfaceName) {
+		tunnels = append(tunnels, entity.VpnTunnel{
+			ProjectName:   wg.LegacyInterfaceName,
+			InterfaceName: wg.LegacyInterfaceName,
+		})
+	}
+	return tunnels
+}
+
+// getVpnTunnelStatuses checks all tunnels in parallel
+func getVpnTunnelStatuses(ctx context.Context, tunnels []entity.VpnTunnel) []vpnTunnelStatus {
+	statuses := make([]vpnTunnelStatus, len(tunnels))
+
+	var waitGroup sync.WaitGroup
+	for i, tunnel := range tunnels {
+		waitGroup.Add(1)
+		go func(i int, tunnel entity.VpnTunnel) {
+			defer waitGroup.Done()
+			statuses[i] = getVpnTunnelStatus(ctx, tunnel)
+		}(i, tunnel)
+	}
+	waitGroup.Wait()
+
+	return statuses
+}
+
+func getVpnTunnelStatus(ctx context.Context, tunnel entity.VpnTunnel) vpnTunnelStatus {
+	status := vpnTunnelStatus{
+		ProjectId:      string(tunnel.ProjectId),
+		ProjectName:    tunnel.ProjectName,
+		InterfaceName:  tunnel.InterfaceName,
+		ConfigFilePath: tunnel.ConfigFilePath,
+		InterfaceUp:    wg.IsInterfaceUp(tunnel.InterfaceName),
+	}
+	if !status.InterfaceUp {
+		return status
+	}
+
+	deviceStatus, err := wg.ReadDeviceStatus(tunnel.InterfaceName)
+	if err != nil {
+		status.DeviceError = err.Error()
+	} else {
+		status.Connected = deviceStatus.IsConnected()
+		status.Endpoint = deviceStatus.Endpoint
+		status.ReceiveBytes = deviceStatus.ReceiveBytes
+		status.TransmitBytes = deviceStatus.TransmitBytes
+		status.AllowedIps = deviceStatus.AllowedIps
+		if !deviceStatus.LastHandshake.IsZero() {
+			status.LastHandshake = &deviceStatus.LastHandshake
+		}
+	}
+
+	// the system resolver has to route the zerops domain into the tunnel
+	if result := nettools.ProbeDns(ctx, "", vpnCheckAddress); !result.Ok() {
+		status.Resolver = i18n.T(i18n.VpnStatusResolverFailed)
+		status.ResolverError = result.Err.Error()
+	} else {
+		status.Resolver = i18n.T(i18n.VpnStatusResolverOk)
+	}
+
+	// ICMP may be unavailable without privileges, the project DNS on the gateway answers through the tunnel too
+	if tunnel.Gateway != "" {
+		result := nettools.ProbeIcmp(ctx, tunnel.Gateway)
+		if !result.Ok() {
+			result = nettools.ProbeDns(ctx, tunnel.Gateway, vpnCheckAddress)
+		}
+		if result.Ok() {
+			status.RttMs = float64(result.Rtt.Microseconds()) / 1000
+			status.RttProbe = string(result.Kind)
+		} else {
+			status.RttError = result.Err.Error()
+		}
+	}
+
+	// without access to the device a working project DNS is the best sign of a connection
+	if status.DeviceError != "" {
+		status.Connected = status.RttError == "" && tunnel.Gateway != ""
+	}
+
+	return status
+}
+
+func (s vpnTunnelStatus) stateText() string {
+	switch {
+	case s.Connected:
+		return i18n.T(i18n.VpnCheckingConnectionIsActive)
+	case s.InterfaceUp:
+		return i18n.T(i18n.VpnStatusNoHandshake)
+	default:
+		return i18n.T(i18n.VpnCheckingConnectionIsNotActive)
+	}
+}
+
+func (s vpnTunnelStatus) handshakeText() string {
+	if s.LastHandshake == nil {
+		return "-"
+	}
+	return i18n.T(i18n.VpnStatusHandshakeAgo, time.Since(*s.LastHandshake).Round(time.Second))
+}
+
+func (s vpnTunnelStatus) rttText() string {
+	if s.RttMs == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f ms (%s)", s.RttMs, s.RttProbe)
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
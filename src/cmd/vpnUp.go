@@ -21,134 +21,241 @@ import (
 	"github.com/zeropsio/zcli/src/uxBlock/styles"
 	"github.com/zeropsio/zcli/src/uxHelpers"
 	"github.com/zeropsio/zcli/src/wg"
-	"github.com/zeropsio/zerops-go/dto/input/body"
-	"github.com/zeropsio/zerops-go/dto/input/path"
-	"github.com/zeropsio/zerops-go/types"
+	"github.com/zeropsio/zerops-go/dto/output"
 	"github.com/zeropsio/zerops-go/types/uuid"
 )
 
-const vpnCheckAddress = "logger.core.zerops"
+const (
+	vpnCheckAddress = "logger.core.zerops"
+	// vpnCheckTargets are probed after the tunnel is created, see nettools.ParseTarget for the format
+	defaultVpnCheckTargets = "icmp:" + vpnCheckAddress
+)
 
 func vpnUpCmd() *cmdBuilder.Cmd {
 	return cmdBuilder.NewCmd().
 		Use("up").
 		Short(i18n.T(i18n.CmdDescVpnUp)).
 		ScopeLevel(scope.Project).
-		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg()).
+		Arg(scope.ProjectArgName, cmdBuilder.OptionalArg(), cmdBuilder.ArgCompletion(scope.ProjectCompletion)).
 		BoolFlag("auto-disconnect", false, i18n.T(i18n.VpnAutoDisconnectFlag)).
+		BoolFlag("userspace", false, i18n.T(i18n.VpnUserspaceFlag)).
+		StringFlag("proxy-address", defaultProxyAddress, i18n.T(i18n.VpnProxyAddressFlag)).
+		StringFlag("forward", "", i18n.T(i18n.VpnForwardFlag)).
+		IntFlag("mtu", wg.DefaultMtu, i18n.T(i18n.VpnMtuFlag)).
+		StringFlag("checkTargets", defaultVpnCheckTargets, i18n.T(i18n.VpnCheckTargetsFlag)).
+		DurationFlag("maxKeyAge", 0, i18n.T(i18n.VpnMaxKeyAgeFlag)).
+		BoolFlag("watch", false, i18n.T(i18n.VpnWatchFlag)).
+		DurationFlag("watchInterval", defaultVpnWatchInterval, i18n.T(i18n.VpnWatchIntervalFlag)).
+		BoolFlag("background", false, i18n.T(i18n.VpnBackgroundFlag)).
+		BoolFlag("attach", false, "", cmdBuilder.HiddenFlag()).
+		EnumFlag("dns", string(wg.DnsModeAuto), wg.DnsModeNames(), i18n.T(i18n.VpnDnsFlag)).
 		HelpFlag(i18n.T(i18n.CmdHelpVpnUp)).
 		LoggedUserRunFunc(func(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData) error {
-			uxBlocks := cmdData.UxBlocks
-
-			if isVpnUp(ctx, uxBlocks, 1) {
-				if cmdData.Params.GetBool("auto-disconnect") {
-					err := disconnectVpn(ctx, uxBlocks)
-					if err != nil {
-						return err
-					}
-				} else {
-					confirmed, err := uxHelpers.YesNoPrompt(
-						ctx,
-						cmdData.UxBlocks,
-						i18n.T(i18n.VpnDisconnectionPrompt),
-					)
-					if err != nil {
-						return err
-					}
-					if !confirmed {
-						return errors.New(i18n.T(i18n.VpnDisconnectionPromptNo))
-					}
-
-					err = disconnectVpn(ctx, uxBlocks)
-					if err != nil {
-						return err
-					}
-				}
-			}
-
-			privateKey, err := getOrCreatePrivateVpnKey(cmdData)
+			maxKeyAge, err := getMaxVpnKeyAge(cmdData)
 			if err != nil {
 				return err
 			}
 
-			publicKey := privateKey.PublicKey()
-
-			postProjectResponse, err := cmdData.RestApiClient.PostProjectVpn(
-				ctx,
-				path.ProjectId{Id: cmdData.Project.ID},
-				body.PostProjectVpn{PublicKey: types.String(publicKey.String())},
-			)
-			if err != nil {
-				return err
+			if cmdData.Params.GetBool("userspace") {
+				return vpnUpUserspace(ctx, cmdData, maxKeyAge)
 			}
 
-			vpnSettings, err := postProjectResponse.Output()
+			checkTargets, err := nettools.ParseTargets(cmdData.Params.GetString("checkTargets"))
 			if err != nil {
-				return err
+				return errors.Wrap(err, i18n.T(i18n.VpnCheckTargetsInvalid))
 			}
 
-			filePath, fileMode, err := constants.WgConfigFilePath()
-			if err != nil {
-				return err
-			}
+			interfaceName := vpnInterfaceName(cmdData.CliStorage, cmdData.Project.ID)
 
-			f, err := file.Open(filePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, fileMode)
-			if err != nil {
-				return err
+			watch, background := cmdData.Params.GetBool("watch"), cmdData.Params.GetBool("background")
+			if background && !watch {
+				return errors.New(i18n.T(i18n.VpnBackgroundWithoutWatch))
 			}
-
-			err = wg.GenerateConfig(f, privateKey, vpnSettings)
+			watchInterval, err := getVpnWatchInterval(cmdData)
 			if err != nil {
 				return err
 			}
 
-			uxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnConfigSaved), filePath))
-
-			_, err = cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
-				if data.VpnKeys == nil {
-					data.VpnKeys = make(map[uuid.ProjectId]entity.VpnKey)
+			// the background watcher is started for a tunnel which is already up
+			if !cmdData.Params.GetBool("attach") {
+				dnsMode, err := getVpnDnsMode(cmdData)
+				if err != nil {
+					return err
 				}
-				data.VpnKeys[cmdData.Project.ID] = entity.VpnKey{
-					ProjectId: cmdData.Project.ID,
-					Key:       privateKey.String(),
-					CreatedAt: time.Now(),
+				err = upVpnTunnel(ctx, cmdData, interfaceName, dnsMode, maxKeyAge, checkTargets)
+				if err != nil {
+					return err
 				}
-
-				return data
-			})
-			if err != nil {
-				return err
 			}
 
-			err = wg.CheckWgInstallation()
-			if err != nil {
-				return err
+			if !watch {
+				return nil
 			}
-
-			c := wg.UpCmd(ctx, filePath)
-			_, err = cmdRunner.Run(c)
-			if err != nil {
-				return err
+			if background {
+				return startVpnWatcher(cmdData, interfaceName, watchInterval, cmdData.Params.GetString("checkTargets"))
 			}
+			return watchVpn(ctx, cmdData, interfaceName, checkTargets, watchInterval)
+		})
+}
 
-			// wait for the vpn to be up
-			if isVpnUp(ctx, uxBlocks, 6) {
-				uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnUp)))
-			} else {
-				uxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnPingFailed)))
-			}
+// upVpnTunnel replaces an active tunnel of the project and connects it again
+func upVpnTunnel(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	interfaceName string,
+	dnsMode wg.DnsMode,
+	maxKeyAge time.Duration,
+	checkTargets []nettools.Target,
+) error {
+	uxBlocks := cmdData.UxBlocks
 
-			return nil
-		})
+	// tunnels of other projects stay connected, the single tunnel of older zcli versions would clash
+	projectTunnel, exists := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]
+	if !exists {
+		projectTunnel = entity.VpnTunnel{ProjectId: cmdData.Project.ID, ProjectName: cmdData.Project.Name.String(), InterfaceName: interfaceName}
+	}
+	var activeTunnels []entity.VpnTunnel
+	for _, tunnel := range []entity.VpnTunnel{
+		projectTunnel,
+		{ProjectName: wg.LegacyInterfaceName, InterfaceName: wg.LegacyInterfaceName},
+	} {
+		// a watched tunnel is stopped even while it is being reconnected
+		if wg.IsInterfaceUp(tunnel.InterfaceName) || (tunnel.WatcherPid != 0 && cmdRunner.IsProcessRunning(tunnel.WatcherPid)) {
+			activeTunnels = append(activeTunnels, tunnel)
+		}
+	}
+
+	if len(activeTunnels) > 0 && !cmdData.Params.GetBool("auto-disconnect") {
+		confirmed, err := uxHelpers.YesNoPrompt(
+			ctx,
+			cmdData.UxBlocks,
+			i18n.T(i18n.VpnDisconnectionPrompt),
+		)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New(i18n.T(i18n.VpnDisconnectionPromptNo))
+		}
+	}
+	for _, tunnel := range activeTunnels {
+		if err := disconnectVpn(ctx, cmdData, tunnel); err != nil {
+			return err
+		}
+	}
+
+	privateKey, vpnSettings, err := registerVpnKey(ctx, cmdData, maxKeyAge)
+	if err != nil {
+		return err
+	}
+
+	err = connectVpn(ctx, cmdData, interfaceName, dnsMode, privateKey, vpnSettings)
+	if err != nil {
+		return err
+	}
+
+	// wait for the vpn to be up
+	if isVpnUp(ctx, uxBlocks, checkTargets, 6) {
+		uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnUp)))
+	} else {
+		uxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnPingFailed)))
+	}
+
+	return nil
+}
+
+// vpnInterfaceName keeps the interface of a connected tunnel, a new tunnel gets a name no other tunnel uses
+func vpnInterfaceName(storageHandler *cliStorage.Handler, projectId uuid.ProjectId) string {
+	tunnels := storageHandler.Data().VpnTunnels
+	if tunnel, exists := tunnels[projectId]; exists && tunnel.InterfaceName != "" {
+		return tunnel.InterfaceName
+	}
+	usedBy := make(map[string]uuid.ProjectId, len(tunnels))
+	for id, tunnel := range tunnels {
+		usedBy[tunnel.InterfaceName] = id
+	}
+	return wg.UniqueInterfaceName(projectId, usedBy)
 }
 
-func isVpnUp(ctx context.Context, uxBlocks uxBlock.UxBlocks, attempts int) bool {
+// connectVpn writes the config of the project tunnel and brings it up by wg-quick
+func connectVpn(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	interfaceName string,
+	dnsMode wg.DnsMode,
+	privateKey wgtypes.Key,
+	vpnSettings output.ProjectVpnItem,
+) error {
+	if os.Getenv(constants.CliWgConfigPathEnvVar) != "" && os.Getenv(constants.CliWgConfigDirPathEnvVar) == "" {
+		cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnConfigEnvDeprecated, constants.CliWgConfigPathEnvVar, constants.CliWgConfigDirPathEnvVar)))
+	}
+
+	filePath, fileMode, err := constants.WgConfigFilePath(interfaceName)
+	if err != nil {
+		return err
+	}
+
+	f, err := file.Open(filePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = wg.GenerateConfig(f, privateKey, vpnSettings, dnsMode)
+	if err != nil {
+		return err
+	}
+
+	cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnConfigSaved), filePath))
+
+	err = wg.CheckWgInstallation()
+	if err != nil {
+		return err
+	}
+
+	c := wg.UpCmd(ctx, filePath)
+	_, err = cmdRunner.Run(c)
+	if err != nil {
+		return withVpnDoctorHint(err)
+	}
+
+	gateway := string(vpnSettings.Project.Ipv4.Network.Gateway)
+	if dnsMode == wg.DnsModeHosts {
+		if err := writeVpnHosts(ctx, cmdData, interfaceName, gateway); err != nil {
+			return err
+		}
+	}
+
+	_, err = cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+		if data.VpnTunnels == nil {
+			data.VpnTunnels = make(map[uuid.ProjectId]entity.VpnTunnel)
+		}
+		data.VpnTunnels[cmdData.Project.ID] = entity.VpnTunnel{
+			ProjectId:      cmdData.Project.ID,
+			ProjectName:    cmdData.Project.Name.String(),
+			InterfaceName:  interfaceName,
+			ConfigFilePath: filePath,
+			Gateway:        gateway,
+			CreatedAt:      time.Now(),
+			DnsMode:        string(dnsMode),
+			ContextName:    cmdData.CliStorage.ContextName(),
+		}
+		return data
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isVpnUp succeeds once all targets answer within the given number of attempts
+func isVpnUp(ctx context.Context, uxBlocks uxBlock.UxBlocks, targets []nettools.Target, attempts int) bool {
 	p := []uxHelpers.Process{
 		{
 			F: func(ctx context.Context) error {
 				for i := 0; i < attempts; i++ {
-					err := nettools.Ping(ctx, vpnCheckAddress)
-					if err == nil {
+					if probeVpnTargets(ctx, uxBlocks, targets) {
 						return nil
 					}
 
@@ -167,24 +274,12 @@ func isVpnUp(ctx context.Context, uxBlocks uxBlock.UxBlocks, attempts int) bool
 	return err == nil
 }
 
-func getOrCreatePrivateVpnKey(cmdData *cmdBuilder.LoggedUserCmdData) (wgtypes.Key, error) {
-	projectId := cmdData.Project.ID
-
-	if vpnKey, exists := cmdData.VpnKeys[projectId]; exists {
-		wgKey, err := wgtypes.ParseKey(vpnKey.Key)
-		if err == nil {
-			return wgKey, nil
-		}
-
-		cmdData.UxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnPrivateKeyCorrupted)))
+func probeVpnTargets(ctx context.Context, uxBlocks uxBlock.UxBlocks, targets []nettools.Target) bool {
+	ok := true
+	for _, target := range targets {
+		result := target.Probe(ctx)
+		uxBlocks.LogDebug(result.String())
+		ok = ok && result.Ok()
 	}
-
-	vpnKey, err := wgtypes.GeneratePrivateKey()
-	if err != nil {
-		return wgtypes.Key{}, err
-	}
-
-	cmdData.UxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnPrivateKeyCreated)))
-
-	return vpnKey, nil
+	return ok
 }
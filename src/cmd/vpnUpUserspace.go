@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/proxyServer"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const defaultProxyAddress = "127.0.0.1:1080"
+
+type portForward struct {
+	localAddress  string
+	remoteAddress string
+}
+
+// vpnUpUserspace runs the tunnel inside the zcli process until Ctrl+C, the project network is available
+// only through the local proxy and the forwarded ports
+func vpnUpUserspace(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, maxKeyAge time.Duration) error {
+	uxBlocks := cmdData.UxBlocks
+
+	forwards, err := parsePortForwards(cmdData.Params.GetString("forward"))
+	if err != nil {
+		return err
+	}
+
+	privateKey, vpnSettings, err := registerVpnKey(ctx, cmdData, maxKeyAge)
+	if err != nil {
+		return err
+	}
+
+	tunnel, err := wg.NewUserspaceTunnel(privateKey, vpnSettings, cmdData.Params.GetInt("mtu"), func(format string, args ...any) {
+		uxBlocks.LogDebug(fmt.Sprintf(format, args...))
+	})
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+
+	proxy := proxyServer.New(proxyServer.Config{
+		Dial: tunnel.DialContext,
+		OnError: func(err error) {
+			uxBlocks.LogDebug(err.Error())
+		},
+	})
+
+	// all listeners are opened first, so that a taken port fails the command before anything is served
+	var listeners []net.Listener
+	closeListeners := func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}
+
+	proxyListener, err := net.Listen("tcp", cmdData.Params.GetString("proxy-address"))
+	if err != nil {
+		return errors.Wrap(err, i18n.T(i18n.VpnProxyListenFailed))
+	}
+	listeners = append(listeners, proxyListener)
+
+	forwardListeners := make([]net.Listener, len(forwards))
+	for i, forward := range forwards {
+		forwardListeners[i], err = net.Listen("tcp", forward.localAddress)
+		if err != nil {
+			closeListeners()
+			return errors.Wrap(err, i18n.T(i18n.VpnProxyListenFailed))
+		}
+		listeners = append(listeners, forwardListeners[i])
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var waitGroup sync.WaitGroup
+	errs := make(chan error, len(listeners))
+	serve := func(f func() error) {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if err := f(); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+
+	serve(func() error { return proxy.Serve(ctx, proxyListener) })
+	uxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnProxyListening), proxyListener.Addr().String()))
+
+	for i, forward := range forwards {
+		listener, remoteAddress := forwardListeners[i], forward.remoteAddress
+		serve(func() error { return proxy.Forward(ctx, listener, remoteAddress) })
+		uxBlocks.PrintInfo(styles.InfoWithValueLine(
+			i18n.T(i18n.VpnForwardListening),
+			fmt.Sprintf("%s -> %s", listener.Addr().String(), remoteAddress),
+		))
+	}
+
+	uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnUserspaceUp)))
+
+	<-ctx.Done()
+	waitGroup.Wait()
+	close(errs)
+
+	uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnDown)))
+
+	return <-errs
+}
+
+// parsePortForwards parses a comma separated list of localPort:host:remotePort,
+// local ports are bound to the loopback interface only
+func parsePortForwards(value string) ([]portForward, error) {
+	var forwards []portForward
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 || parts[1] == "" || !isPort(parts[0]) || !isPort(parts[2]) {
+			return nil, errors.New(i18n.T(i18n.VpnForwardInvalid, item))
+		}
+
+		forwards = append(forwards, portForward{
+			localAddress:  net.JoinHostPort("127.0.0.1", parts[0]),
+			remoteAddress: net.JoinHostPort(parts[1], parts[2]),
+		})
+	}
+	return forwards, nil
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port <= 65535
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/backoff"
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/cmdBuilder"
+	"github.com/zeropsio/zcli/src/cmdRunner"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/nettools"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+	"github.com/zeropsio/zcli/src/wg"
+)
+
+const (
+	defaultVpnWatchInterval = 15 * time.Second
+	vpnReconnectMinDelay    = 5 * time.Second
+	vpnReconnectMaxDelay    = 5 * time.Minute
+	// vpnWatcherStopTimeout is how long vpn down waits for a background watcher to disconnect the tunnel
+	vpnWatcherStopTimeout = 15 * time.Second
+)
+
+func getVpnWatchInterval(cmdData *cmdBuilder.LoggedUserCmdData) (time.Duration, error) {
+	watchInterval := cmdData.Params.GetDuration("watchInterval")
+	if watchInterval <= 0 {
+		return 0, errors.New(i18n.T(i18n.VpnWatchIntervalInvalid))
+	}
+	return watchInterval, nil
+}
+
+// watchVpn checks the tunnel every interval and re-creates it with a backoff once it is stale.
+// The tunnel is disconnected when the context is canceled, e.g. by Ctrl+C or by vpn down.
+func watchVpn(
+	ctx context.Context,
+	cmdData *cmdBuilder.LoggedUserCmdData,
+	interfaceName string,
+	checkTargets []nettools.Target,
+	watchInterval time.Duration,
+) error {
+	uxBlocks := cmdData.UxBlocks
+
+	if err := setVpnWatcher(cmdData, os.Getpid(), watchInterval, cmdData.Params.GetString("checkTargets")); err != nil {
+		return err
+	}
+
+	uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnWatching)))
+	uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: started, interval %s", interfaceName, watchInterval))
+
+	reconnectBackoff := backoff.New(vpnReconnectMinDelay, vpnReconnectMaxDelay, 0)
+	healthy := true
+	delay := watchInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: stopped", interfaceName))
+			return disconnectWatchedVpn(cmdData, interfaceName)
+		case <-time.After(delay):
+		}
+
+		reason := checkVpnHealth(ctx, cmdData, interfaceName, checkTargets)
+		if ctx.Err() != nil {
+			continue
+		}
+
+		if reason == "" {
+			if !healthy {
+				uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: healthy", interfaceName))
+				uxBlocks.PrintInfo(styles.InfoLine(i18n.T(i18n.VpnWatchRecovered)))
+			}
+			healthy = true
+			reconnectBackoff.Reset()
+			delay = watchInterval
+			continue
+		}
+
+		if healthy {
+			uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: stale, %s", interfaceName, reason))
+		}
+		healthy = false
+
+		uxBlocks.PrintWarning(styles.WarningLine(i18n.T(i18n.VpnWatchReconnecting, reason)))
+		if err := reconnectVpn(ctx, interfaceName); err != nil {
+			uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: reconnect failed, %s", interfaceName, err))
+		} else {
+			uxBlocks.LogDebug(fmt.Sprintf("vpn watch %s: reconnected, attempt %d", interfaceName, reconnectBackoff.Attempt()+1))
+		}
+
+		// the next check waits at least the watch interval, the backoff spaces out repeated failures
+		delay, _ = reconnectBackoff.Next()
+		if delay < watchInterval {
+			delay = watchInterval
+		}
+	}
+}
+
+// checkVpnHealth returns the reason why the tunnel is considered stale, an empty string means it is healthy
+func checkVpnHealth(ctx context.Context, cmdData *cmdBuilder.LoggedUserCmdData, interfaceName string, checkTargets []nettools.Target) string {
+	if !wg.IsInterfaceUp(interfaceName) {
+		return i18n.T(i18n.VpnWatchInterfaceMissing)
+	}
+
+	// reading the device needs privileges, without them only the probes are used
+	if status, err := wg.ReadDeviceStatus(interfaceName); err == nil && !status.IsConnected() {
+		if status.LastHandshake.IsZero() {
+			return i18n.T(i18n.VpnStatusNoHandshake)
+		}
+		return i18n.T(i18n.VpnWatchHandshakeTooOld, time.Since(status.LastHandshake).Round(time.Second))
+	}
+
+	if !probeVpnTargets(ctx, cmdData.UxBlocks, checkTargets) {
+		return i18n.T(i18n.VpnWatchProbesFailed)
+	}
+
+	return ""
+}
+
+// reconnectVpn re-creates the tunnel from its config file, the key and the peer stay the same
+func reconnectVpn(ctx context.Context, interfaceName string) error {
+	filePath, _, err := constants.WgConfigFilePath(interfaceName)
+	if err != nil {
+		return err
+	}
+
+	if wg.IsInterfaceUp(interfaceName) {
+		if _, err := cmdRunner.Run(wg.DownCmd(ctx, filePath)); err != nil {
+			return err
+		}
+	}
+	if _, err := cmdRunner.Run(wg.UpCmd(ctx, filePath)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// disconnectWatchedVpn runs after the context is canceled, wg-quick must not be killed by it
+func disconnectWatchedVpn(cmdData *cmdBuilder.LoggedUserCmdData, interfaceName string) error {
+	tunnel, exists := cmdData.CliStorage.Data().VpnTunnels[cmdData.Project.ID]
+	if !exists {
+		return nil
+	}
+	tunnel.InterfaceName = interfaceName
+	return disconnectVpn(context.Background(), cmdData, tunnel)
+}
+
+// startVpnWatcher runs the watcher as a detached zcli process, it is stopped by vpn down
+func startVpnWatcher(cmdData *cmdBuilder.LoggedUserCmdData, interfaceName string, watchInterval time.Duration, checkTargets string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	args := []string{
+		"vpn", "up",
+		"--projectId", string(cmdData.Project.ID),
+		"--watch", "--attach",
+		"--watchInterval", watchInterval.String(),
+		"--checkTargets", checkTargets,
+	}
+	watcherCmd := exec.Command(executable, args...)
+	// the watcher keeps the login of this invocation, the current context may be switched meanwhile
+	watcherCmd.Env = []string{constants.CliContextEnvVar + "=" + cmdData.CliStorage.ContextName()}
+	if cmdData.CliStorage.IsEnvLogin() {
+		data := cmdData.CliStorage.Data()
+		watcherCmd.Env = append(watcherCmd.Env,
+			constants.CliTokenEnvVar+"="+data.Token,
+			constants.CliRegionEnvVar+"="+data.RegionData.Name,
+		)
+	}
+	pid, err := cmdRunner.StartDetached(watcherCmd)
+	if err != nil {
+		return err
+	}
+
+	cmdData.UxBlocks.PrintInfo(styles.InfoWithValueLine(i18n.T(i18n.VpnWatcherStarted), fmt.Sprintf("%s, pid %d", interfaceName, pid)))
+
+	return nil
+}
+
+func setVpnWatcher(cmdData *cmdBuilder.LoggedUserCmdData, pid int, watchInterval time.Duration, checkTargets string) error {
+	_, err := cmdData.CliStorage.Update(func(data cliStorage.Data) cliStorage.Data {
+		if tunnel, exists := data.VpnTunnels[cmdData.Project.ID]; exists {
+			tunnel.WatcherPid = pid
+			tunnel.WatchInterval = watchInterval
+			tunnel.CheckTargets = checkTargets
+			data.VpnTunnels[cmdData.Project.ID] = tunnel
+		}
+		return data
+	})
+	return err
+}
+
+// stopVpnWatcher stops the background watcher of the tunnel and waits until it disconnects
+func stopVpnWatcher(pid int) error {
+	if pid == os.Getpid() || !cmdRunner.IsProcessRunning(pid) {
+		return nil
+	}
+	if err := cmdRunner.StopProcess(pid); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(vpnWatcherStopTimeout)
+	for cmdRunner.IsProcessRunning(pid) {
+		if time.Now().After(deadline) {
+			return errors.New(i18n.T(i18n.VpnWatcherStopFailed, pid))
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return nil
+}
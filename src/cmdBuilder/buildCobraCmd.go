@@ -2,7 +2,9 @@ package cmdBuilder
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeropsio/zcli/src/cliStorage"
@@ -44,17 +46,44 @@ func buildCobraCmd(
 	}
 
 	for _, flag := range cmd.flags {
+		// persistent flags are read by createCmdRunFunc of the subcommands, not by the flag params
+		if flag.persistent {
+			cobraCmd.PersistentFlags().StringP(flag.name, flag.shorthand, flag.defaultValue.(string), flag.description)
+			continue
+		}
+
 		switch defaultValue := flag.defaultValue.(type) {
 		case string:
-			flagParams.RegisterString(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
+			if flag.enumValues == nil {
+				flagParams.RegisterString(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
+				break
+			}
+			flagParams.RegisterEnum(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.enumValues, flag.description)
+			err := cobraCmd.RegisterFlagCompletionFunc(flag.name, cobra.FixedCompletions(flag.enumValues, cobra.ShellCompDirectiveNoFileComp))
+			if err != nil {
+				return nil, err
+			}
 		case int:
 			flagParams.RegisterInt(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
 		case bool:
 			flagParams.RegisterBool(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
+		case time.Duration:
+			flagParams.RegisterDuration(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
+		case []string:
+			flagParams.RegisterStringSlice(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
+		case map[string]string:
+			flagParams.RegisterStringMap(cobraCmd, flag.name, flag.shorthand, defaultValue, flag.description)
 		default:
 			panic(fmt.Sprintf("unexpected type %T", flag.defaultValue))
 		}
 
+		if flag.completion != nil {
+			err := cobraCmd.RegisterFlagCompletionFunc(flag.name, createCompletionFunc(cmd, flag.completion, flagParams, uxBlocks, cliStorage))
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if flag.hidden {
 			err := cobraCmd.Flags().MarkHidden(flag.name)
 			if err != nil {
@@ -63,6 +92,10 @@ func buildCobraCmd(
 		}
 	}
 
+	if slices.ContainsFunc(cmd.args, func(arg cmdArg) bool { return arg.completion != nil }) {
+		cobraCmd.ValidArgsFunction = createArgsCompletionFunc(cmd, flagParams, uxBlocks, cliStorage)
+	}
+
 	if cmd.guestRunFunc != nil || cmd.loggedUserRunFunc != nil {
 		cobraCmd.RunE = createCmdRunFunc(cmd, flagParams, uxBlocks, cliStorage)
 	}
@@ -0,0 +1,35 @@
+package cmdBuilder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zeropsio/zcli/src/flagParams"
+)
+
+func TestBuildCobraCmdSameUse(t *testing.T) {
+	runFunc := func(ctx context.Context, cmdData *LoggedUserCmdData) error { return nil }
+	deleteCmd := func() *Cmd {
+		return NewCmd().Use("delete").BoolFlag("confirm", false, "").LoggedUserRunFunc(runFunc)
+	}
+	rootCmd := NewCmd().Use("zcli").
+		AddChildrenCmd(NewCmd().Use("service").AddChildrenCmd(deleteCmd())).
+		AddChildrenCmd(NewCmd().Use("vpn").AddChildrenCmd(NewCmd().Use("key").AddChildrenCmd(deleteCmd())))
+
+	params := flagParams.New()
+	cobraCmd, err := buildCobraCmd(rootCmd, params, nil, nil)
+	require.NoError(t, err)
+
+	serviceDelete, _, err := cobraCmd.Find([]string{"service", "delete"})
+	require.NoError(t, err)
+	require.NoError(t, serviceDelete.ParseFlags([]string{"--confirm"}))
+
+	vpnKeyDelete, _, err := cobraCmd.Find([]string{"vpn", "key", "delete"})
+	require.NoError(t, err)
+
+	// vpn key delete is registered later, it must not replace the param of service delete
+	require.True(t, params.GetBool(serviceDelete, "confirm"))
+	require.False(t, params.GetBool(vpnKeyDelete, "confirm"))
+}
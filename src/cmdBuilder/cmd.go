@@ -2,6 +2,7 @@ package cmdBuilder
 
 import (
 	"context"
+	"time"
 )
 
 type loggedUserRunFunc func(ctx context.Context, cmdData *LoggedUserCmdData) error
@@ -35,6 +36,7 @@ type cmdArg struct {
 	optional      bool
 	isArray       bool
 	optionalLabel string
+	completion    CompletionFunc
 }
 
 type cmdFlag struct {
@@ -43,6 +45,10 @@ type cmdFlag struct {
 	description  string
 	hidden       bool
 	shorthand    string
+	persistent   bool
+	// enumValues are the allowed values of an enum flag, they are offered by the shell completion
+	enumValues []string
+	completion CompletionFunc
 }
 
 func NewCmd() *Cmd {
@@ -121,6 +127,13 @@ func OptionalArgLabel(label string) ArgOption {
 	}
 }
 
+// ArgCompletion offers values of the arg in the shell completion
+func ArgCompletion(completion CompletionFunc) ArgOption {
+	return func(cfg *cmdArg) {
+		cfg.completion = completion
+	}
+}
+
 func (cmd *Cmd) Arg(name string, auxOptions ...ArgOption) *Cmd {
 	cfg := cmdArg{
 		name: name,
@@ -141,6 +154,20 @@ func HiddenFlag() FlagOption {
 	}
 }
 
+// PersistentFlag makes the flag available in all subcommands, only string flags are supported
+func PersistentFlag() FlagOption {
+	return func(cfg *cmdFlag) {
+		cfg.persistent = true
+	}
+}
+
+// FlagCompletion offers values of the flag in the shell completion
+func FlagCompletion(completion CompletionFunc) FlagOption {
+	return func(cfg *cmdFlag) {
+		cfg.completion = completion
+	}
+}
+
 func ShortHand(shorthand string) FlagOption {
 	return func(cfg *cmdFlag) {
 		cfg.shorthand = shorthand
@@ -159,6 +186,26 @@ func (cmd *Cmd) BoolFlag(name string, defaultValue bool, description string, aux
 	return cmd.addFlag(name, defaultValue, description, auxOptions...)
 }
 
+// EnumFlag is a string flag which value must be one of the allowed values, an empty default value means the value is optional
+func (cmd *Cmd) EnumFlag(name string, defaultValue string, allowedValues []string, description string, auxOptions ...FlagOption) *Cmd {
+	auxOptions = append(auxOptions, func(cfg *cmdFlag) {
+		cfg.enumValues = allowedValues
+	})
+	return cmd.addFlag(name, defaultValue, description, auxOptions...)
+}
+
+func (cmd *Cmd) DurationFlag(name string, defaultValue time.Duration, description string, auxOptions ...FlagOption) *Cmd {
+	return cmd.addFlag(name, defaultValue, description, auxOptions...)
+}
+
+func (cmd *Cmd) StringSliceFlag(name string, defaultValue []string, description string, auxOptions ...FlagOption) *Cmd {
+	return cmd.addFlag(name, defaultValue, description, auxOptions...)
+}
+
+func (cmd *Cmd) StringMapFlag(name string, defaultValue map[string]string, description string, auxOptions ...FlagOption) *Cmd {
+	return cmd.addFlag(name, defaultValue, description, auxOptions...)
+}
+
 func (cmd *Cmd) HelpFlag(description string, auxOptions ...FlagOption) *Cmd {
 	auxOptions = append(auxOptions, ShortHand("h"))
 	return cmd.addFlag("help", false, description, auxOptions...)
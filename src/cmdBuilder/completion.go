@@ -0,0 +1,138 @@
+package cmdBuilder
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/flagParams"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock"
+	"github.com/zeropsio/zcli/src/zeropsRestApiClient"
+)
+
+// CompletionShells are the shells with a completion script
+var CompletionShells = []string{"bash", "zsh", "fish"}
+
+// CompletionFunc returns values offered by the shell completion, a value may be followed by a tab and its description
+type CompletionFunc func(ctx context.Context, cmdData *CompletionCmdData) ([]string, error)
+
+type CompletionCmdData struct {
+	*GuestCmdData
+	// RestApiClient is nil if the user is not logged in
+	RestApiClient *zeropsRestApiClient.Handler
+	ToComplete    string
+
+	cache          *completionCache
+	cacheKeyPrefix string
+}
+
+// Cached returns values of the key retrieved by the API within the last minute, the cache is separated per login
+func (d *CompletionCmdData) Cached(key string, retrieve func() ([]string, error)) ([]string, error) {
+	if d.cache == nil {
+		return retrieve()
+	}
+	return d.cache.get(d.cacheKeyPrefix+key, retrieve)
+}
+
+func createCompletionFunc(
+	cmd *Cmd,
+	completionFunc CompletionFunc,
+	flagParams *flagParams.Handler,
+	uxBlocks uxBlock.UxBlocks,
+	cliStorage *cliStorage.Handler,
+) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cobraCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx := cobraCmd.Context()
+
+		flagParams.InitViper()
+
+		// args are not complete yet, an invalid count is not an error here
+		argsMap, _ := convertArgs(cmd, args)
+
+		cmdData := &CompletionCmdData{
+			GuestCmdData: &GuestCmdData{
+				CliStorage: cliStorage,
+				UxBlocks:   uxBlocks,
+				Args:       argsMap,
+				Params:     newCmdParamReader(cobraCmd, flagParams),
+			},
+			ToComplete: toComplete,
+		}
+
+		if err := selectCliContext(cobraCmd, cliStorage); err != nil {
+			uxBlocks.LogDebug(fmt.Sprintf("completion error: %+v", err))
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if err := applyEnvLogin(ctx, cliStorage); err != nil {
+			uxBlocks.LogDebug(fmt.Sprintf("completion error: %+v", err))
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		storedData := cliStorage.Data()
+		if storedData.Token != "" {
+			cmdData.RestApiClient = zeropsRestApiClient.NewAuthorizedClient(storedData.Token, "https://"+storedData.RegionData.Address)
+			// the token is not stored in the cache file, only its hash separates values of different logins
+			cmdData.cacheKeyPrefix = hashCompletionLogin(storedData.RegionData.Address, storedData.Token)
+			if cacheFilePath, err := constants.CompletionCacheFilePath(); err == nil {
+				cmdData.cache = &completionCache{filePath: cacheFilePath, ttl: completionCacheTtl}
+			}
+		}
+
+		values, err := completionFunc(ctx, cmdData)
+		if err != nil {
+			uxBlocks.LogDebug(fmt.Sprintf("completion error: %+v", err))
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func hashCompletionLogin(regionAddress, token string) string {
+	hash := sha256.Sum256([]byte(regionAddress + "/" + token))
+	return fmt.Sprintf("%x/", hash[:8])
+}
+
+// createArgsCompletionFunc completes the arg at the position of the word being completed, the last array arg takes all remaining words
+func createArgsCompletionFunc(
+	cmd *Cmd,
+	flagParams *flagParams.Handler,
+	uxBlocks uxBlock.UxBlocks,
+	cliStorage *cliStorage.Handler,
+) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cobraCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		index := len(args)
+		if index >= len(cmd.args) {
+			last := len(cmd.args) - 1
+			if last < 0 || !cmd.args[last].isArray {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			index = last
+		}
+
+		arg := cmd.args[index]
+		if arg.completion == nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return createCompletionFunc(cmd, arg.completion, flagParams, uxBlocks, cliStorage)(cobraCmd, args, toComplete)
+	}
+}
+
+func printCompletion(rootCmd *cobra.Command, shell string) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	default:
+		return errors.New(i18n.T(i18n.CompletionShellUnknown, shell))
+	}
+}
@@ -0,0 +1,66 @@
+package cmdBuilder
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// completionCacheTtl is short, the completion runs on every tab press and the values should still be up to date
+const completionCacheTtl = time.Minute
+
+type completionCacheItem struct {
+	Values      []string
+	RetrievedAt time.Time
+}
+
+// completionCache keeps values of the shell completion in a file, every completion is a new zcli process
+type completionCache struct {
+	filePath string
+	ttl      time.Duration
+}
+
+// get returns fresh cached values of the key, otherwise the values are retrieved and stored
+func (c *completionCache) get(key string, retrieve func() ([]string, error)) ([]string, error) {
+	items := c.load()
+	if item, exists := items[key]; exists && time.Since(item.RetrievedAt) < c.ttl {
+		return item.Values, nil
+	}
+
+	values, err := retrieve()
+	if err != nil {
+		return nil, err
+	}
+
+	for k, item := range items {
+		if time.Since(item.RetrievedAt) >= c.ttl {
+			delete(items, k)
+		}
+	}
+	items[key] = completionCacheItem{Values: values, RetrievedAt: time.Now()}
+
+	// the cache is only an optimization, the values are returned even if it can't be written
+	_ = c.save(items)
+	return values, nil
+}
+
+// load returns an empty cache if the file doesn't exist or can't be read
+func (c *completionCache) load() map[string]completionCacheItem {
+	items := make(map[string]completionCacheItem)
+	content, err := os.ReadFile(c.filePath)
+	if err != nil {
+		return items
+	}
+	if err := json.Unmarshal(content, &items); err != nil {
+		return make(map[string]completionCacheItem)
+	}
+	return items
+}
+
+func (c *completionCache) save(items map[string]completionCacheItem) error {
+	content, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.filePath, content, 0600)
+}
@@ -0,0 +1,44 @@
+package cmdBuilder
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCache(t *testing.T) {
+	cache := &completionCache{filePath: filepath.Join(t.TempDir(), "cli.data.completion"), ttl: time.Minute}
+
+	var calls int
+	retrieve := func() ([]string, error) {
+		calls++
+		return []string{"id\tname"}, nil
+	}
+
+	values, err := cache.get("projects", retrieve)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id\tname"}, values)
+
+	// a new process reads the values from the file
+	cache = &completionCache{filePath: cache.filePath, ttl: time.Minute}
+	values, err = cache.get("projects", retrieve)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id\tname"}, values)
+	require.Equal(t, 1, calls)
+
+	_, err = cache.get("services", func() ([]string, error) {
+		return nil, errors.New("unavailable")
+	})
+	require.EqualError(t, err, "unavailable")
+
+	// expired values are retrieved again
+	cache.ttl = 0
+	_, err = cache.get("projects", retrieve)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	require.NotEqual(t, hashCompletionLogin("prg1", "token1"), hashCompletionLogin("prg1", "token2"))
+}
@@ -1,24 +1,36 @@
 package cmdBuilder
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/zeropsio/zcli/src/cliStorage"
+	"github.com/zeropsio/zcli/src/constants"
 	"github.com/zeropsio/zcli/src/entity"
 	"github.com/zeropsio/zcli/src/flagParams"
+	"github.com/zeropsio/zcli/src/httpClient"
 	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/region"
 	"github.com/zeropsio/zcli/src/uxBlock"
 	"github.com/zeropsio/zcli/src/zeropsRestApiClient"
 	"github.com/zeropsio/zerops-go/types/uuid"
 )
 
+// ContextFlagName is the global flag selecting the login context for a single invocation
+const ContextFlagName = "context"
+
 type ParamsReader interface {
 	GetString(name string) string
 	GetInt(name string) int
 	GetBool(name string) bool
+	GetDuration(name string) time.Duration
+	GetStringSlice(name string) []string
+	GetStringMap(name string) map[string]string
 }
 
 type CmdParamReader struct {
@@ -45,6 +57,18 @@ func (r *CmdParamReader) GetBool(name string) bool {
 	return r.paramsHandler.GetBool(r.cobraCmd, name)
 }
 
+func (r *CmdParamReader) GetDuration(name string) time.Duration {
+	return r.paramsHandler.GetDuration(r.cobraCmd, name)
+}
+
+func (r *CmdParamReader) GetStringSlice(name string) []string {
+	return r.paramsHandler.GetStringSlice(r.cobraCmd, name)
+}
+
+func (r *CmdParamReader) GetStringMap(name string) map[string]string {
+	return r.paramsHandler.GetStringMap(r.cobraCmd, name)
+}
+
 type GuestCmdData struct {
 	CliStorage *cliStorage.Handler
 	UxBlocks   uxBlock.UxBlocks
@@ -52,6 +76,8 @@ type GuestCmdData struct {
 	Params     ParamsReader
 
 	PrintHelp func()
+	// PrintCompletion prints the completion script of the whole zcli for the shell, see CompletionShells
+	PrintCompletion func(shell string) error
 }
 
 type LoggedUserCmdData struct {
@@ -77,6 +103,9 @@ func createCmdRunFunc(
 		uxBlocks.LogDebug(fmt.Sprintf("Command: %s", cobraCmd.CommandPath()))
 
 		flagParams.InitViper()
+		if err := flagParams.Validate(cobraCmd); err != nil {
+			return err
+		}
 
 		argsMap, err := convertArgs(cmd, args)
 		if err != nil {
@@ -92,6 +121,16 @@ func createCmdRunFunc(
 			PrintHelp: func() {
 				cobraCmd.HelpFunc()(cobraCmd, []string{})
 			},
+			PrintCompletion: func(shell string) error {
+				return printCompletion(cobraCmd.Root(), shell)
+			},
+		}
+
+		if err := selectCliContext(cobraCmd, cliStorage); err != nil {
+			return err
+		}
+		if err := applyEnvLogin(ctx, cliStorage); err != nil {
+			return err
 		}
 
 		storedData := cliStorage.Data()
@@ -126,6 +165,47 @@ func createCmdRunFunc(
 	}
 }
 
+// selectCliContext overrides the current login context by the --context flag or the ZEROPS_CONTEXT env variable
+func selectCliContext(cobraCmd *cobra.Command, cliStorage *cliStorage.Handler) error {
+	contextName := os.Getenv(constants.CliContextEnvVar)
+	if flag := cobraCmd.Flags().Lookup(ContextFlagName); flag != nil && flag.Changed {
+		contextName = flag.Value.String()
+	}
+	if contextName == "" {
+		return nil
+	}
+	return cliStorage.SelectContext(contextName)
+}
+
+// applyEnvLogin uses ZEROPS_TOKEN and ZEROPS_REGION instead of the stored login, they are never stored
+func applyEnvLogin(ctx context.Context, cliStorage *cliStorage.Handler) error {
+	token := os.Getenv(constants.CliTokenEnvVar)
+	if token == "" {
+		return nil
+	}
+	regionName := os.Getenv(constants.CliRegionEnvVar)
+
+	// the stored region spares the request for the region list
+	storedRegion := cliStorage.Data().RegionData
+	if storedRegion.Address != "" && (regionName == "" || regionName == storedRegion.Name) {
+		cliStorage.SetEnvLogin(token, storedRegion)
+		return nil
+	}
+
+	regionRetriever := region.New(httpClient.New(ctx, httpClient.Config{HttpTimeout: time.Minute}))
+	regions, err := cliStorage.RetrieveRegions(ctx, regionRetriever, constants.DefaultRegionUrl, region.CacheTtl)
+	if err != nil {
+		return err
+	}
+	for _, reg := range regions {
+		if reg.Name == regionName || (regionName == "" && reg.IsDefault) {
+			cliStorage.SetEnvLogin(token, reg)
+			return nil
+		}
+	}
+	return errors.New(i18n.T(i18n.RegionNotFound, regionName))
+}
+
 func convertArgs(cmd *Cmd, args []string) (map[string][]string, error) {
 	var requiredArgsCount int
 	var isArray bool
@@ -18,6 +18,7 @@ import (
 	"github.com/zeropsio/zcli/src/flagParams"
 	"github.com/zeropsio/zcli/src/i18n"
 	"github.com/zeropsio/zcli/src/logger"
+	"github.com/zeropsio/zcli/src/secretStore"
 	"github.com/zeropsio/zcli/src/storage"
 	"github.com/zeropsio/zcli/src/support"
 	"github.com/zeropsio/zcli/src/uxBlock"
@@ -59,6 +60,9 @@ func ExecuteRootCmd(rootCmd *Cmd) (err error) {
 		return err
 	}
 
+	// the completion command is a regular command of the root, see GuestCmdData.PrintCompletion
+	cobraCmd.CompletionOptions.DisableDefaultCmd = true
+
 	err = cobraCmd.ExecuteContext(ctx)
 	if err != nil {
 		printError(err, uxBlocks)
@@ -151,10 +155,28 @@ func createCliStorage() (*cliStorage.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the last fallback location may be readable by other users, secrets are written there only into a secret store
+	var plaintextSecretsErr error
+	if constants.IsCliDataTempFilePath(filePath) {
+		plaintextSecretsErr = errors.New(i18n.T(
+			i18n.SecretStoreTempDirRefused,
+			filePath,
+			constants.CliDataFilePathEnvVar,
+			constants.CliSecretPassphraseEnvVar,
+		))
+	}
 	s, err := storage.New[cliStorage.Data](
 		storage.Config{
 			FilePath: filePath,
 			FileMode: fileMode,
+			SecretStore: func(storedName string) (storage.SecretStore, error) {
+				return secretStore.Detect(filePath, storedName)
+			},
+			OpenSecretStore: func(name string) (storage.SecretStore, error) {
+				return secretStore.Open(name, filePath)
+			},
+			Migrations:          cliStorage.Migrations,
+			PlaintextSecretsErr: plaintextSecretsErr,
 		},
 	)
 	return &cliStorage.Handler{Handler: s}, err
@@ -0,0 +1,31 @@
+package cmdRunner
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// StartDetached starts the command as a background process which outlives zcli, its output is discarded
+func StartDetached(cmd *exec.Cmd) (int, error) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer devNull.Close()
+
+	cmd.Stdin = devNull
+	cmd.Stdout = devNull
+	cmd.Stderr = devNull
+	cmd.Env = append(os.Environ(), cmd.Env...)
+	cmd.SysProcAttr = detachedSysProcAttr()
+
+	if err := cmd.Start(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	pid := cmd.Process.Pid
+
+	// the process is not waited for, release its resources right away
+	return pid, errors.WithStack(cmd.Process.Release())
+}
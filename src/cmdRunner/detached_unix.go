@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package cmdRunner
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// detachedSysProcAttr starts a new session, so that the process doesn't receive signals of the terminal
+func detachedSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// IsProcessRunning reports whether a process with the pid exists
+func IsProcessRunning(pid int) bool {
+	return pid > 0 && syscall.Kill(pid, 0) == nil
+}
+
+// StopProcess asks the process to terminate gracefully
+func StopProcess(pid int) error {
+	return errors.WithStack(syscall.Kill(pid, syscall.SIGTERM))
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package cmdRunner
+
+import "os"
+
+// IsPrivileged reports whether the process runs as root, wg-quick needs it to create interfaces
+func IsPrivileged() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package cmdRunner
+
+import "golang.org/x/sys/windows"
+
+// IsPrivileged reports whether the process runs as an elevated administrator
+func IsPrivileged() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
@@ -14,7 +14,15 @@ func getDataFilePathsReceivers() []pathReceiver {
 		receiverFromOsFunc(os.UserConfigDir, ZeropsDir, CliDataFileName),
 		receiverFromOsFunc(os.UserHomeDir, ZeropsDir, CliDataFileName),
 		receiverFromOsFunc(os.UserHomeDir, "zerops."+CliDataFileName),
-		receiverFromOsTemp("zerops." + CliDataFileName),
+		receiverFromOsTemp(cliDataTempFileName),
+	}
+}
+
+func getConfigFilePathReceivers() []pathReceiver {
+	return []pathReceiver{
+		receiverFromEnv(CliConfigFilePathEnvVar),
+		receiverFromOsFunc(os.UserConfigDir, ZeropsDir, CliConfigFileName),
+		receiverFromOsFunc(os.UserHomeDir, ZeropsDir, CliConfigFileName),
 	}
 }
 
@@ -29,15 +37,16 @@ func getLogFilePathReceivers() []pathReceiver {
 	}
 }
 
-func getWgConfigFilePathReceivers() []pathReceiver {
+func getWgConfigFilePathReceivers(fileName string) []pathReceiver {
 	return []pathReceiver{
-		receiverFromEnv(CliWgConfigPathEnvVar),
-		receiverFromPath(path.Join("/etc/wireguard/", WgConfigFile)),
-		receiverFromPath(path.Join("/usr/local/etc/wireguard/", WgConfigFile)),
-		receiverFromPath(path.Join("/opt/homebrew/etc/wireguard/", WgConfigFile)),
-		receiverFromOsFunc(os.UserConfigDir, ZeropsDir, WgConfigFile),
-		receiverFromOsFunc(os.UserHomeDir, ZeropsDir, WgConfigFile),
-		receiverFromOsFunc(os.UserHomeDir, WgConfigFile),
-		receiverFromOsTemp("zerops." + WgConfigFile),
+		receiverFromEnvJoin(CliWgConfigDirPathEnvVar, fileName),
+		receiverFromEnvDir(CliWgConfigPathEnvVar, fileName),
+		receiverFromPath(path.Join("/etc/wireguard/", fileName)),
+		receiverFromPath(path.Join("/usr/local/etc/wireguard/", fileName)),
+		receiverFromPath(path.Join("/opt/homebrew/etc/wireguard/", fileName)),
+		receiverFromOsFunc(os.UserConfigDir, ZeropsDir, fileName),
+		receiverFromOsFunc(os.UserHomeDir, ZeropsDir, fileName),
+		receiverFromOsFunc(os.UserHomeDir, fileName),
+		receiverFromOsTemp(ZeropsDir, fileName),
 	}
 }
@@ -8,7 +8,6 @@ import (
 )
 
 // this is here to make linter happy
-var _ = ZeropsDir
 var _ = receiverFromPath
 
 func getDataFilePathsReceivers() []pathReceiver {
@@ -17,7 +16,15 @@ func getDataFilePathsReceivers() []pathReceiver {
 		receiverFromOsFunc(os.UserConfigDir, "Zerops", CliDataFileName),
 		receiverFromOsFunc(os.UserHomeDir, "Zerops", CliDataFileName),
 		receiverFromOsFunc(os.UserHomeDir, "zerops."+CliDataFileName),
-		receiverFromOsTemp("zerops." + CliDataFileName),
+		receiverFromOsTemp(cliDataTempFileName),
+	}
+}
+
+func getConfigFilePathReceivers() []pathReceiver {
+	return []pathReceiver{
+		receiverFromEnv(CliConfigFilePathEnvVar),
+		receiverFromOsFunc(os.UserConfigDir, "Zerops", CliConfigFileName),
+		receiverFromOsFunc(os.UserHomeDir, "Zerops", CliConfigFileName),
 	}
 }
 
@@ -31,12 +38,13 @@ func getLogFilePathReceivers() []pathReceiver {
 	}
 }
 
-func getWgConfigFilePathReceivers() []pathReceiver {
+func getWgConfigFilePathReceivers(fileName string) []pathReceiver {
 	return []pathReceiver{
-		receiverFromEnv(CliWgConfigPathEnvVar),
-		receiverFromOsFunc(os.UserConfigDir, "Zerops", WgConfigFile),
-		receiverFromOsFunc(os.UserHomeDir, "Zerops", WgConfigFile),
-		receiverFromOsFunc(os.UserHomeDir, WgConfigFile),
-		receiverFromOsTemp("zerops." + WgConfigFile),
+		receiverFromEnvJoin(CliWgConfigDirPathEnvVar, fileName),
+		receiverFromEnvDir(CliWgConfigPathEnvVar, fileName),
+		receiverFromOsFunc(os.UserConfigDir, "Zerops", fileName),
+		receiverFromOsFunc(os.UserHomeDir, "Zerops", fileName),
+		receiverFromOsFunc(os.UserHomeDir, fileName),
+		receiverFromOsTemp(ZeropsDir, fileName),
 	}
 }
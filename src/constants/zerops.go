@@ -13,29 +13,93 @@ import (
 )
 
 const (
-	DefaultRegionUrl      = "https://api.app-prg1.zerops.io/api/rest/public/region/zcli"
-	ZeropsDir             = "zerops"
-	ZeropsLogFile         = "zerops.log"
-	WgConfigFile          = "zerops.conf"
-	CliDataFileName       = "cli.data"
+	DefaultRegionUrl = "https://api.app-prg1.zerops.io/api/rest/public/region/zcli"
+	ZeropsDir        = "zerops"
+	ZeropsLogFile    = "zerops.log"
+	WgConfigFileExt  = ".conf"
+	CliDataFileName  = "cli.data"
+	// cliDataTempFileName is the last fallback of the data file, it is in the temp dir
+	cliDataTempFileName = "zerops." + CliDataFileName
+	// CompletionCacheFileExt is appended to the data file path, the cache is kept next to the data file
+	CompletionCacheFileExt = ".completion"
+	// CliConfigFileName is the name of the user config file, project config files may use any extension supported by viper
+	CliConfigFileName     = "zcli.config.yaml"
 	CliDataFilePathEnvVar = "ZEROPS_CLI_DATA_FILE_PATH"
 	CliLogFilePathEnvVar  = "ZEROPS_CLI_LOG_FILE_PATH"
-	CliWgConfigPathEnvVar = "ZEROPS_WG_CONFIG_FILE_PATH"
-	CliTerminalMode       = "ZEROPS_CLI_TERMINAL_MODE"
+	// CliWgConfigPathEnvVar is deprecated, only the directory of the file is used since every tunnel has its own config file
+	CliWgConfigPathEnvVar    = "ZEROPS_WG_CONFIG_FILE_PATH"
+	CliWgConfigDirPathEnvVar = "ZEROPS_WG_CONFIG_DIR_PATH"
+	CliTerminalMode          = "ZEROPS_CLI_TERMINAL_MODE"
+	// CliSecretStoreEnvVar is one of auto, keyring, file or plain
+	CliSecretStoreEnvVar      = "ZEROPS_SECRET_STORE"
+	CliSecretPassphraseEnvVar = "ZEROPS_SECRET_PASSPHRASE"
+	CliContextEnvVar          = "ZEROPS_CONTEXT"
+	CliTokenEnvVar            = "ZEROPS_TOKEN"
+	CliRegionEnvVar           = "ZEROPS_REGION"
+	CliConfigFilePathEnvVar   = "ZEROPS_CLI_CONFIG_FILE_PATH"
 )
 
-type pathReceiver func(fileMode os.FileMode) (path string, err error)
+// pathReceiver returns a candidate path of a file, an error means there is no candidate, e.g. an empty env
+type pathReceiver func() (path string, err error)
+
+// PathCandidate is a possible location of a file, Err says why it can't be used
+type PathCandidate struct {
+	Path string
+	Err  error
+}
 
 func CliDataFilePath() (string, os.FileMode, error) {
 	return checkReceivers(getDataFilePathsReceivers(), 0600, i18n.UnableToWriteCliData)
 }
 
+// IsCliDataTempFilePath reports whether the data file is the fallback in the temp dir
+func IsCliDataTempFilePath(filePath string) bool {
+	return filePath == filepath.Join(os.TempDir(), cliDataTempFileName)
+}
+
+// CompletionCacheFilePath returns the file with values of the shell completion retrieved from the API
+func CompletionCacheFilePath() (string, error) {
+	dataFilePath, _, err := CliDataFilePath()
+	if err != nil {
+		return "", err
+	}
+	return dataFilePath + CompletionCacheFileExt, nil
+}
+
+// CliConfigFilePath returns the user config file, unlike the data file it is created only when a value is set
+func CliConfigFilePath() (string, error) {
+	var errs []string
+	for _, p := range getConfigFilePathReceivers() {
+		path, err := p()
+		if err == nil {
+			return path, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return "", errors.New(i18n.T(i18n.UnableToFindConfigFile, "\n"+strings.Join(errs, "\n")+"\n"))
+}
+
 func LogFilePath() (string, os.FileMode, error) {
 	return checkReceivers(getLogFilePathReceivers(), 0666, i18n.UnableToWriteLogFile)
 }
 
-func WgConfigFilePath() (string, os.FileMode, error) {
-	return checkReceivers(getWgConfigFilePathReceivers(), 0600, i18n.UnableToWriteLogFile)
+// WgConfigFilePath returns the config file of a tunnel, wg-quick derives the interface name from the file name
+func WgConfigFilePath(interfaceName string) (string, os.FileMode, error) {
+	return checkReceivers(getWgConfigFilePathReceivers(interfaceName+WgConfigFileExt), 0600, i18n.UnableToWriteLogFile)
+}
+
+// WgConfigFilePathCandidates checks every location of the tunnel config file without creating anything
+func WgConfigFilePathCandidates(interfaceName string) []PathCandidate {
+	pathReceivers := getWgConfigFilePathReceivers(interfaceName + WgConfigFileExt)
+	candidates := make([]PathCandidate, 0, len(pathReceivers))
+	for _, p := range pathReceivers {
+		path, err := p()
+		if err == nil {
+			err = checkPathWritable(path)
+		}
+		candidates = append(candidates, PathCandidate{Path: path, Err: err})
+	}
+	return candidates
 }
 
 func checkReceivers(pathReceivers []pathReceiver, fileMode os.FileMode, errorText string) (string, os.FileMode, error) {
@@ -43,7 +107,10 @@ func checkReceivers(pathReceivers []pathReceiver, fileMode os.FileMode, errorTex
 	if path == "" {
 		paths := make([]string, 0, len(pathReceivers))
 		for _, p := range pathReceivers {
-			_, err := p(fileMode)
+			path, err := p()
+			if err == nil {
+				_, err = checkPath(path, fileMode)
+			}
 			paths = append(paths, err.Error())
 		}
 		return "", 0, errors.New(i18n.T(errorText, "\n"+strings.Join(paths, "\n")+"\n"))
@@ -52,42 +119,67 @@ func checkReceivers(pathReceivers []pathReceiver, fileMode os.FileMode, errorTex
 }
 
 func receiverFromPath(path string) pathReceiver {
-	return func(fileMode os.FileMode) (string, error) {
-		return checkPath(path, fileMode)
+	return func() (string, error) {
+		return path, nil
 	}
 }
 
 func receiverFromEnv(envName string) pathReceiver {
-	return func(fileMode os.FileMode) (string, error) {
+	return func() (string, error) {
 		env := os.Getenv(envName)
 		if env == "" {
 			return "", errors.Errorf("env %s is empty", envName)
 		}
-		return checkPath(env, fileMode)
+		return env, nil
+	}
+}
+
+// receiverFromEnvJoin places the file into the directory from the env
+func receiverFromEnvJoin(envName string, fileName string) pathReceiver {
+	return func() (string, error) {
+		env := os.Getenv(envName)
+		if env == "" {
+			return "", errors.Errorf("env %s is empty", envName)
+		}
+		return filepath.Join(env, fileName), nil
+	}
+}
+
+// receiverFromEnvDir places the file next to the path from the env, so that every tunnel gets its own file
+func receiverFromEnvDir(envName string, fileName string) pathReceiver {
+	return func() (string, error) {
+		env := os.Getenv(envName)
+		if env == "" {
+			return "", errors.Errorf("env %s is empty", envName)
+		}
+		return filepath.Join(filepath.Dir(env), fileName), nil
 	}
 }
 
 func receiverFromOsFunc(osFunc func() (string, error), elem ...string) pathReceiver {
-	return func(fileMode os.FileMode) (string, error) {
+	return func() (string, error) {
 		dir, err := osFunc()
 		if err != nil {
 			return "", err
 		}
 
-		return checkPath(filepath.Join(append([]string{dir}, elem...)...), fileMode)
+		return filepath.Join(append([]string{dir}, elem...)...), nil
 	}
 }
 
 func receiverFromOsTemp(elem ...string) pathReceiver {
-	return func(fileMode os.FileMode) (string, error) {
-		return checkPath(filepath.Join(append([]string{os.TempDir()}, elem...)...), fileMode)
+	return func() (string, error) {
+		return filepath.Join(append([]string{os.TempDir()}, elem...)...), nil
 	}
 }
 
 func findFirstWritablePath(paths []pathReceiver, fileMode os.FileMode) string {
 	for _, p := range paths {
-		path, err := p(fileMode)
-		if err == nil {
+		path, err := p()
+		if err != nil {
+			continue
+		}
+		if path, err = checkPath(path, fileMode); err == nil {
 			return path
 		}
 	}
@@ -113,3 +205,34 @@ func checkPath(filePath string, fileMode os.FileMode) (string, error) {
 
 	return filePath, nil
 }
+
+// checkPathWritable checks the file or the closest existing directory of the file can be written
+func checkPathWritable(filePath string) error {
+	if _, err := os.Stat(filePath); err == nil {
+		f, err := os.OpenFile(filePath, os.O_RDWR, 0)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	dir := filepath.Dir(filePath)
+	for {
+		if info, err := os.Stat(dir); err == nil {
+			if !info.IsDir() {
+				return errors.Errorf("%s is not a directory", dir)
+			}
+			f, err := os.CreateTemp(dir, ".zcli-*")
+			if err != nil {
+				return err
+			}
+			f.Close()
+			return os.Remove(f.Name())
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return errors.Errorf("%s does not exist", filePath)
+		}
+		dir = parent
+	}
+}
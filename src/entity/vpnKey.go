@@ -7,7 +7,8 @@ import (
 )
 
 type VpnKey struct {
-	Key       string
-	ProjectId uuid.ProjectId
-	CreatedAt time.Time
+	Key         string
+	ProjectId   uuid.ProjectId
+	ProjectName string
+	CreatedAt   time.Time
 }
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+type VpnTunnel struct {
+	ProjectId      uuid.ProjectId
+	ProjectName    string
+	InterfaceName  string
+	ConfigFilePath string
+	// Gateway is the project DNS server, reachable only through the tunnel
+	Gateway   string
+	CreatedAt time.Time
+	// WatcherPid is the process of vpn up --watch --background, zero when the tunnel isn't watched
+	WatcherPid int
+	// WatchInterval and CheckTargets are the settings of the watcher, it is restarted with them, e.g. by vpn key rotate
+	WatchInterval time.Duration
+	CheckTargets  string
+	// DnsMode is the resolved wg.DnsMode, the hosts mode needs a cleanup after the tunnel is down
+	DnsMode string
+	// ContextName is the login context which connected the tunnel, empty for the default one
+	ContextName string
+}
@@ -2,28 +2,70 @@ package flagParams
 
 import (
 	"fmt"
-	"os"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/cliConfig"
+	"github.com/zeropsio/zcli/src/i18n"
 )
 
 type Handler struct {
-	params map[string]interface{}
-	viper  *viper.Viper
+	params     map[paramId]interface{}
+	validators map[*cobra.Command][]func() error
+	viper      *viper.Viper
 }
 
 func New() *Handler {
 	return &Handler{
-		params: make(map[string]interface{}),
-		viper:  viper.New(),
+		params:     make(map[paramId]interface{}),
+		validators: make(map[*cobra.Command][]func() error),
+		viper:      viper.New(),
 	}
 }
 
-func (h *Handler) getCmdId(cmd *cobra.Command, name string) string {
-	return cmd.Use + name
+// paramId is keyed by the command itself, different commands may share the same Use, e.g. service delete and vpn key delete
+type paramId struct {
+	cmd  *cobra.Command
+	name string
+}
+
+func (h *Handler) getCmdId(cmd *cobra.Command, name string) paramId {
+	return paramId{cmd: cmd, name: name}
+}
+
+// register stores the getter of the param value, a changed flag wins over env variables and config files,
+// the default value is used only if the param is not set anywhere, so zero values are respected too
+func register[T any](h *Handler, cmd *cobra.Command, name string, paramValue *T, convert func(value interface{}) (T, error)) {
+	getValue := func() (*T, error) {
+		key := cliConfig.Key(name)
+		if cmd.Flags().Lookup(name).Changed || !h.viper.IsSet(key) {
+			return paramValue, nil
+		}
+		value := h.viper.Get(key)
+		converted, err := convert(value)
+		if err != nil {
+			return paramValue, errors.New(i18n.T(i18n.FlagValueInvalid, fmt.Sprint(value), "--"+name, err))
+		}
+		return &converted, nil
+	}
+
+	h.params[h.getCmdId(cmd, name)] = func() *T {
+		value, _ := getValue()
+		return value
+	}
+	h.addValidator(cmd, func() error {
+		_, err := getValue()
+		return err
+	})
+}
+
+func (h *Handler) addValidator(cmd *cobra.Command, validator func() error) {
+	h.validators[cmd] = append(h.validators[cmd], validator)
 }
 
 func (h *Handler) RegisterString(cmd *cobra.Command, name, shorthand, defaultValue, description string) {
@@ -31,15 +73,7 @@ func (h *Handler) RegisterString(cmd *cobra.Command, name, shorthand, defaultVal
 
 	cmd.Flags().StringVarP(&paramValue, name, shorthand, defaultValue, description)
 
-	h.params[h.getCmdId(cmd, name)] = func() *string {
-		if cmd.Flags().Lookup(name).Changed {
-			return &paramValue
-		}
-		if val := h.viper.GetString(toSnakeCase(name)); val != "" {
-			return &val
-		}
-		return &paramValue
-	}
+	register(h, cmd, name, &paramValue, cast.ToStringE)
 }
 
 func (h *Handler) RegisterBool(cmd *cobra.Command, name, shorthand string, defaultValue bool, description string) {
@@ -47,15 +81,7 @@ func (h *Handler) RegisterBool(cmd *cobra.Command, name, shorthand string, defau
 
 	cmd.Flags().BoolVarP(&paramValue, name, shorthand, defaultValue, description)
 
-	h.params[h.getCmdId(cmd, name)] = func() *bool {
-		if cmd.Flags().Lookup(name).Changed {
-			return &paramValue
-		}
-		if val := h.viper.GetBool(toSnakeCase(name)); val {
-			return &val
-		}
-		return &paramValue
-	}
+	register(h, cmd, name, &paramValue, cast.ToBoolE)
 }
 
 func (h *Handler) RegisterInt(cmd *cobra.Command, name, shorthand string, defaultValue int, description string) {
@@ -63,76 +89,145 @@ func (h *Handler) RegisterInt(cmd *cobra.Command, name, shorthand string, defaul
 
 	cmd.Flags().IntVarP(&paramValue, name, shorthand, defaultValue, description)
 
-	h.params[h.getCmdId(cmd, name)] = func() *int {
-		if cmd.Flags().Lookup(name).Changed {
-			return &paramValue
+	register(h, cmd, name, &paramValue, cast.ToIntE)
+}
+
+// RegisterEnum registers a string param which value must be one of the allowed values, the case is ignored
+func (h *Handler) RegisterEnum(cmd *cobra.Command, name, shorthand, defaultValue string, allowedValues []string, description string) {
+	h.RegisterString(cmd, name, shorthand, defaultValue, description)
+
+	h.addValidator(cmd, func() error {
+		value := h.GetString(cmd, name)
+		if value == "" && defaultValue == "" {
+			return nil
 		}
-		if val := h.viper.GetInt(toSnakeCase(name)); val != 0 {
-			return &val
+		for _, allowed := range allowedValues {
+			if strings.EqualFold(value, allowed) {
+				return nil
+			}
 		}
-		return &paramValue
-	}
+		return errors.New(i18n.T(i18n.FlagValueNotAllowed, value, "--"+name, strings.Join(allowedValues, ", ")))
+	})
 }
 
-func (h *Handler) GetString(cmd *cobra.Command, name string) string {
-	id := h.getCmdId(cmd, name)
-	if param, exists := h.params[id]; exists {
-		if v, ok := param.(func() *string); ok {
-			return *v()
+func (h *Handler) RegisterDuration(cmd *cobra.Command, name, shorthand string, defaultValue time.Duration, description string) {
+	var paramValue time.Duration
+
+	cmd.Flags().DurationVarP(&paramValue, name, shorthand, defaultValue, description)
+
+	register(h, cmd, name, &paramValue, toDuration)
+}
+
+func (h *Handler) RegisterStringSlice(cmd *cobra.Command, name, shorthand string, defaultValue []string, description string) {
+	var paramValue []string
+
+	cmd.Flags().StringSliceVarP(&paramValue, name, shorthand, defaultValue, description)
+
+	register(h, cmd, name, &paramValue, toStringSlice)
+}
+
+func (h *Handler) RegisterStringMap(cmd *cobra.Command, name, shorthand string, defaultValue map[string]string, description string) {
+	var paramValue map[string]string
+
+	cmd.Flags().StringToStringVarP(&paramValue, name, shorthand, defaultValue, description)
+
+	register(h, cmd, name, &paramValue, toStringMap)
+}
+
+// Validate checks values of all params of the command, it must be called after InitViper
+func (h *Handler) Validate(cmd *cobra.Command) error {
+	for _, validator := range h.validators[cmd] {
+		if err := validator(); err != nil {
+			return err
 		}
-		return ""
 	}
-	return ""
+	return nil
+}
+
+func (h *Handler) GetString(cmd *cobra.Command, name string) string {
+	return get[string](h, cmd, name)
 }
 
 func (h *Handler) GetInt(cmd *cobra.Command, name string) int {
-	id := h.getCmdId(cmd, name)
-	if param, exists := h.params[id]; exists {
-		if v, ok := param.(func() *int); ok {
-			return *v()
-		}
-		return 0
-	}
-	return 0
+	return get[int](h, cmd, name)
 }
 
 func (h *Handler) GetBool(cmd *cobra.Command, name string) bool {
+	return get[bool](h, cmd, name)
+}
+
+func (h *Handler) GetDuration(cmd *cobra.Command, name string) time.Duration {
+	return get[time.Duration](h, cmd, name)
+}
+
+func (h *Handler) GetStringSlice(cmd *cobra.Command, name string) []string {
+	return get[[]string](h, cmd, name)
+}
+
+func (h *Handler) GetStringMap(cmd *cobra.Command, name string) map[string]string {
+	return get[map[string]string](h, cmd, name)
+}
+
+func get[T any](h *Handler, cmd *cobra.Command, name string) (value T) {
 	id := h.getCmdId(cmd, name)
 	if param, exists := h.params[id]; exists {
-		if v, ok := param.(func() *bool); ok {
+		if v, ok := param.(func() *T); ok {
 			return *v()
 		}
-		return false
 	}
-	return false
+	return value
 }
 
-func (h *Handler) InitViper() {
-	path, err := os.Getwd()
-	if err == nil {
-		h.viper.AddConfigPath(path)
+// toDuration parses strings the same way as the flag does, a plain number is not a valid duration
+func toDuration(value interface{}) (time.Duration, error) {
+	if s, ok := value.(string); ok {
+		return time.ParseDuration(s)
 	}
-	cliDataPath, _, err := constants.CliDataFilePath()
-	if err == nil {
-		h.viper.AddConfigPath(cliDataPath)
-	}
-
-	h.viper.SetConfigName("zcli.config")
-	h.viper.SetEnvPrefix("ZEROPS")
-	h.viper.AutomaticEnv()
+	return cast.ToDurationE(value)
+}
 
-	if err := h.viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", h.viper.ConfigFileUsed())
+// toStringSlice accepts a comma separated list from env variables and a list from config files
+func toStringSlice(value interface{}) ([]string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return cast.ToStringSliceE(value)
+	}
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items, nil
 }
 
-func toSnakeCase(flagName string) string {
-	var result string
-	for i, r := range flagName {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result += "_"
+// toStringMap accepts key=value pairs separated by comma from env variables and a map from config files
+func toStringMap(value interface{}) (map[string]string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return cast.ToStringMapStringE(value)
+	}
+	items := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		key, val, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, errors.Errorf("%s must be formatted as key=value", pair)
 		}
-		result += string(r)
+		items[key] = val
+	}
+	return items, nil
+}
+
+// InitViper reads flag values from env variables and the config files, see cliConfig
+func (h *Handler) InitViper() {
+	h.viper.SetEnvPrefix(cliConfig.EnvPrefix)
+	h.viper.AutomaticEnv()
+
+	// an invalid config file is reported by zcli config list, it must not break other commands
+	if config, err := cliConfig.Load(); err == nil {
+		_ = config.MergeInto(h.viper)
 	}
-	return result
 }
@@ -0,0 +1,81 @@
+package flagParams
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zeropsio/zcli/src/constants"
+)
+
+func TestHandlerPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(workDir)
+
+	configFilePath := filepath.Join(dir, constants.CliConfigFileName)
+	require.NoError(t, os.WriteFile(configFilePath, []byte("follow: false\nlimit: 0\nformat: full\ntags: [a, b]\nlabels:\n  env: prod\n"), 0600))
+	t.Setenv(constants.CliConfigFilePathEnvVar, configFilePath)
+	t.Setenv("ZEROPS_FORMAT", "short")
+	t.Setenv("ZEROPS_SINCE", "0s")
+
+	h := New()
+	cmd := &cobra.Command{Use: "test"}
+	h.RegisterBool(cmd, "follow", "", true, "")
+	h.RegisterInt(cmd, "limit", "", 100, "")
+	h.RegisterEnum(cmd, "format", "", "", []string{"FULL", "SHORT"}, "")
+	h.RegisterDuration(cmd, "since", "", time.Minute, "")
+	h.RegisterStringSlice(cmd, "tags", "", nil, "")
+	h.RegisterStringMap(cmd, "labels", "", nil, "")
+	h.RegisterString(cmd, "output", "", "-", "")
+	h.InitViper()
+
+	require.NoError(t, cmd.Flags().Parse([]string{"--limit", "5"}))
+	require.NoError(t, h.Validate(cmd))
+
+	// zero values from the config file and env variables override the default values
+	require.False(t, h.GetBool(cmd, "follow"))
+	require.Equal(t, time.Duration(0), h.GetDuration(cmd, "since"))
+	// the flag wins over the config file, env variables win over the config file
+	require.Equal(t, 5, h.GetInt(cmd, "limit"))
+	require.Equal(t, "short", h.GetString(cmd, "format"))
+	require.Equal(t, []string{"a", "b"}, h.GetStringSlice(cmd, "tags"))
+	require.Equal(t, map[string]string{"env": "prod"}, h.GetStringMap(cmd, "labels"))
+	require.Equal(t, "-", h.GetString(cmd, "output"))
+
+	t.Setenv("ZEROPS_FORMAT", "xml")
+	require.ErrorContains(t, h.Validate(cmd), "allowed values are FULL, SHORT")
+
+	t.Setenv("ZEROPS_FORMAT", "")
+	t.Setenv("ZEROPS_SINCE", "5")
+	require.ErrorContains(t, h.Validate(cmd), "--since")
+
+	t.Setenv("ZEROPS_SINCE", "1h")
+	t.Setenv("ZEROPS_TAGS", "c, d")
+	t.Setenv("ZEROPS_LABELS", "a=1,b=2")
+	require.NoError(t, h.Validate(cmd))
+	require.Equal(t, "full", h.GetString(cmd, "format"))
+	require.Equal(t, time.Hour, h.GetDuration(cmd, "since"))
+	require.Equal(t, []string{"c", "d"}, h.GetStringSlice(cmd, "tags"))
+	require.Equal(t, map[string]string{"a": "1", "b": "2"}, h.GetStringMap(cmd, "labels"))
+}
+
+func TestHandlerSameUse(t *testing.T) {
+	h := New()
+	serviceDelete := &cobra.Command{Use: "delete"}
+	vpnKeyDelete := &cobra.Command{Use: "delete"}
+	h.RegisterBool(serviceDelete, "confirm", "", false, "")
+	h.RegisterBool(vpnKeyDelete, "confirm", "", false, "")
+
+	require.NoError(t, serviceDelete.Flags().Parse([]string{"--confirm"}))
+
+	require.True(t, h.GetBool(serviceDelete, "confirm"))
+	require.False(t, h.GetBool(vpnKeyDelete, "confirm"))
+}
@@ -7,9 +7,58 @@ var en = map[string]string{
 	CmdHelpLogin:          "the login command.",
 	CmdDescLogin:          "Logs you into Zerops. Use a generated Zerops token or your login e-mail and password.",
 	LoginSuccess:          "You are logged as %s <%s>",
+	LoginTokenStdinFlag:   "Reads the token from the standard input, so that it doesn't appear in the process list or the shell history.",
+	LoginTokenMissing:     "Token is missing, pass it as the argument or by --token-stdin",
+	LoginTokenTwice:       "Pass the token either as the argument or by --token-stdin, not both",
 	RegionNotFound:        "Selected region %s not found",
 	RegionTableColumnName: "Name",
 
+	// config
+	CmdHelpConfig:        "the config command.",
+	CmdDescConfig:        "Config commands group, the config files keep default values of flags",
+	CmdHelpConfigGet:     "the config get command.",
+	CmdDescConfigGet:     "Prints the value of the key, e.g. zcli config get projectId.",
+	CmdHelpConfigSet:     "the config set command.",
+	CmdDescConfigSet:     "Sets the default value of a flag, e.g. zcli config set projectId <id>.",
+	CmdHelpConfigUnset:   "the config unset command.",
+	CmdDescConfigUnset:   "Removes the key from the config file.",
+	CmdHelpConfigList:    "the config list command.",
+	CmdDescConfigList:    "Lists all values set in the config files.",
+	CmdHelpConfigPath:    "the config path command.",
+	CmdDescConfigPath:    "Prints paths of the user and the project config file.",
+	ConfigProjectFlag:    "Uses the project config file in the repository root instead of the user one.",
+	ConfigShowOriginFlag: "Shows the file or the env variable each value comes from.",
+	ConfigValueSet:       "%s was set in %s",
+	ConfigValueUnset:     "%s was removed from %s",
+	ConfigKeyNotSet:      "Key %s isn't set",
+	ConfigNoValues:       "No config values are set",
+	ConfigOriginEnv:      "env %s",
+	ConfigFileInvalid:    "Unable to read the config file %s",
+	ConfigPathUser:       "user",
+	ConfigPathProject:    "project",
+
+	// region
+	CmdHelpRegion:      "the region command.",
+	CmdDescRegion:      "Region commands group",
+	CmdHelpRegionList:  "the region list command.",
+	CmdDescRegionList:  "Lists Zerops regions with their latency, the default one is marked.",
+	CmdHelpRegionUse:   "the region use command.",
+	CmdDescRegionUse:   "Switches the region of the current context, the token is kept.",
+	RegionSwitched:     "Region switched to %s",
+	RegionVpnTunnels:   "VPN tunnels of [%s] were connected in the region %s, they keep working until you disconnect them by zcli vpn down",
+	RegionTokenInvalid: "The token isn't valid in the region %[1]s, log in by zcli login --region %[1]s instead",
+
+	// logout
+	CmdHelpLogout:            "the logout command.",
+	CmdDescLogout:            "Logs you out of Zerops, disconnects VPN tunnels of this login and removes the token.",
+	LogoutAllFlag:            "Removes the project scope and VPN keys as well, peers of the keys are removed from their projects.",
+	LogoutRevokeFlag:         "Revokes the token on the server, if the API supports it for the token.",
+	LogoutSuccess:            "You are logged out of the context %s",
+	LogoutNotLoggedIn:        "You are not logged in to the context %s",
+	LogoutEnvToken:           "The token from %s is used, it stays active until the env variable is unset. The stored login of the context %s is kept.",
+	LogoutRevokeFailed:       "The token couldn't be revoked on the server, remove it in the Zerops GUI: %s",
+	LogoutVpnKeyDeleteFailed: "VPN key of the project %s couldn't be removed from the project, it's forgotten locally only: %s",
+
 	// scope
 	CmdHelpScope: "the scope command.",
 	CmdDescScope: "Scope commands group",
@@ -68,6 +117,12 @@ var en = map[string]string{
 	ServiceStopFailed:  "Service stop failed",
 	ServiceStopped:     "Service was stopped",
 
+	// service port-forward
+	CmdHelpServicePortForward: "the service port-forward command.",
+	CmdDescServicePortForward: "Forwards local ports to the service, e.g. 5432:5432, over the project VPN.\nA connected VPN tunnel is used, otherwise zCLI connects to the project network without root privileges.",
+	ServicePortForwardInvalid: "Invalid port forward [%s], use localPort:remotePort, e.g. 5432:5432",
+	ServicePortForwardRunning: "Port forward is running, press Ctrl+C to stop it",
+
 	// service delete
 	CmdHelpServiceDelete: "the service delete command.",
 	CmdDescServiceDelete: "Deletes the Zerops service.",
@@ -83,20 +138,31 @@ var en = map[string]string{
 		"Use the <serviceName> alone in the command to return log messages from all runtime containers.\n" +
 		"Set <serviceName>@1 to return log messages from the first runtime container only.\n" +
 		"Set <serviceName>@build to return log messages from the last build if available.",
-	LogLimitInvalid:              "Invalid --limit value. Allowed interval is <1;1000>",
-	LogMinSeverityInvalid:        "Invalid --minimumSeverity value.",
-	LogMinSeverityStringLimitErr: "Allowed values are EMERGENCY, ALERT, CRITICAL, ERROR, WARNING, NOTICE, INFORMATIONAL, DEBUG.",
-	LogMinSeverityNumLimitErr:    "Allowed interval is <0;7>.",
-	LogFormatInvalid:             "Invalid --format value. Allowed values are FULL, SHORT, JSON, JSONSTREAM.",
-	LogFormatTemplateMismatch:    "--formatTemplate can be used only in combination with --format=FULL.",
-	LogFormatStreamMismatch:      "--format=JSON cannot be used in combination with --follow. Use --format=JSONSTREAM instead.",
-	LogFormatTemplateInvalid:     "Invalid --formatTemplate content. The custom template failed with following error:",
-	LogFormatTemplateNoSpace:     "Template items must be split by a (single) space.",
-	LogNoBuildFound:              "No build was found for this service.",
-	LogBuildStatusUploading:      "Service status UPLOADING, need to wait for app version data.",
-	LogAccessFailed:              "Request for access to logs failed.",
-	LogMsgTypeInvalid:            "Invalid --messageType value. Allowed values are APPLICATION, WEBSERVER.",
-	LogReadingFailed:             "Log reading failed.",
+	LogLimitInvalid:                   "Invalid --limit value. Allowed interval is <1;1000>, with --since any positive number is allowed.",
+	LogMinSeverityInvalid:             "Invalid --minimumSeverity value.",
+	LogMinSeverityStringLimitErr:      "Allowed values are EMERGENCY, ALERT, CRITICAL, ERROR, WARNING, NOTICE, INFORMATIONAL, DEBUG.",
+	LogMinSeverityNumLimitErr:         "Allowed interval is <0;7>.",
+	LogFormatTemplateMismatch:         "--formatTemplate can be used only in combination with --format=FULL.",
+	LogFormatTemplateInvalid:          "Invalid --formatTemplate content. The custom template failed with following error:",
+	LogNoBuildFound:                   "No build was found for this service.",
+	LogBuildStatusUploading:           "Service status UPLOADING, need to wait for app version data.",
+	LogAccessFailed:                   "Request for access to logs failed.",
+	LogReadingFailed:                  "Log reading failed.",
+	LogStreamReconnecting:             "Log stream interrupted (%v), reconnecting in %s [attempt %d/%d].",
+	LogStreamReconnectBudgetExhausted: "Giving up after %d reconnect attempts, last error: %v",
+	LogStreamPossibleGap:              "Possible log message loss between %s and %s.",
+	LogSinceInvalid:                   "Invalid --since value. Use a positive duration, e.g. 30m or 24h.",
+	LogSinceIncomplete:                "The log API returned no messages older than %s, the output doesn't cover the whole --since window.",
+	LogSinceFollowMismatch:            "--since cannot be used in combination with --follow.",
+	LogRotateMismatch:                 "--rotateSizeMb and --rotateInterval can be used only with --follow and a file --output.",
+	LogRotateSizeInvalid:              "Invalid --rotateSizeMb value. Use a positive number of megabytes.",
+	LogRotateIntervalInvalid:          "Invalid --rotateInterval value. Use a positive duration, e.g. 1h.",
+	LogAlertHookMissing:               "--on-match and --alertSeverity require --exec or --webhook to be set.",
+	LogAlertPatternInvalid:            "Invalid --on-match regular expression:",
+	LogAlertSeverityInvalid:           "Invalid --alertSeverity value.",
+	LogAlertRateLimitInvalid:          "Invalid --alertRateLimit value. The duration must not be negative, e.g. 10s.",
+	LogAlertDedupWindowInvalid:        "Invalid --alertDedup value. The duration must not be negative, e.g. 5m.",
+	LogAlertHookFailed:                "Alert hook failed: %v",
 
 	// service deploy
 	CmdHelpServiceDeploy: "the service deploy command.",
@@ -157,6 +223,52 @@ var en = map[string]string{
 	CmdHelpVersion: "the version command.",
 	CmdDescVersion: "Shows the current zCLI version.",
 
+	// completion
+	CmdHelpCompletion: "the completion command.",
+	CmdDescCompletion: "Prints the shell completion script for bash, zsh or fish.",
+	CmdDescCompletionLong: "Prints the shell completion script for bash, zsh or fish. Project ids, service names and ids\n" +
+		"are completed from your Zerops account, setup names from the local zerops.yaml.\n\n" +
+		"bash:  source <(zcli completion bash)\n" +
+		"zsh:   zcli completion zsh > \"${fpath[1]}/_zcli\"\n" +
+		"fish:  zcli completion fish > ~/.config/fish/completions/zcli.fish",
+	CompletionShellUnknown: "Unknown shell %s. Possible values: bash, zsh, fish.",
+
+	// auth
+	CmdHelpAuth:              "the auth command.",
+	CmdDescAuth:              "Authentication commands group",
+	CmdHelpAuthStatus:        "the auth status command.",
+	CmdDescAuthStatus:        "Shows the login state and where the token and VPN keys are kept.",
+	AuthStatusLoggedIn:       "Logged in",
+	AuthStatusYes:            "yes",
+	AuthStatusNo:             "no",
+	AuthStatusRegion:         "Region",
+	AuthStatusSecrets:        "Secrets",
+	AuthStatusSecretsKeyring: "OS keyring",
+	AuthStatusSecretsKernel:  "linux kernel keyring, kept until restart",
+	AuthStatusSecretsFile:    "encrypted file %s",
+	AuthStatusSecretsPlain:   "plaintext in %s",
+	AuthStatusVpnKeys:        "VPN keys",
+	AuthStatusPlainWarning:   "The token and VPN keys are stored in plaintext. No OS keyring is available, set %s to keep them in an encrypted file.",
+
+	// context
+	CmdHelpContext:        "the context command.",
+	CmdDescContext:        "Login contexts commands group",
+	CmdHelpContextAdd:     "the context add command.",
+	CmdDescContextAdd:     "Adds a new login context and switches to it.",
+	CmdHelpContextUse:     "the context use command.",
+	CmdDescContextUse:     "Switches to the login context.",
+	CmdHelpContextList:    "the context list command.",
+	CmdDescContextList:    "Lists all login contexts.",
+	CmdHelpContextCurrent: "the context current command.",
+	CmdDescContextCurrent: "Prints the name of the current login context.",
+	ContextFlag:           "Login context used for this command, overrides the current one.",
+	ContextAdded:          "Context %s was added and is now current, log in by zcli login to use it.",
+	ContextSwitched:       "Switched to the context %s.",
+	ContextNotFound:       "Context %s doesn't exist, add it by zcli context add.",
+	ContextAlreadyExists:  "Context %s already exists.",
+	ContextInvalidName:    "Context name %s is invalid, use only letters, digits, dots, dashes and underscores.",
+	ContextNotLoggedIn:    "not logged in",
+
 	// vpn
 	CmdHelpVpn: "the vpn command.",
 	CmdDescVpn: "VPN commands group",
@@ -167,6 +279,7 @@ var en = map[string]string{
 	VpnUp:        "VPN connected",
 
 	VpnConfigSaved:           "VPN config saved",
+	VpnConfigEnvDeprecated:   "%s is deprecated, every tunnel has its own config file in the directory of the path, use %s with the directory instead",
 	VpnPrivateKeyCorrupted:   "VPN private key corrupted, a new one will be created",
 	VpnPrivateKeyCreated:     "VPN private key created",
 	VpnDisconnectionPrompt:   "VPN is active, do you want to disconnect?",
@@ -175,36 +288,166 @@ var en = map[string]string{
 	VpnPingFailed: fmt.Sprintf("Wireguard adapter was created, but we are not able to establish a connection,"+
 		"this could indicate a problem on our side. Please contact our support team %s.", CustomerSupportLink),
 
+	VpnUserspaceUp:       "Userspace VPN connected, press Ctrl+C to disconnect",
+	VpnProxyListening:    "SOCKS5/HTTP proxy listening on",
+	VpnProxyListenFailed: "Unable to open a local port",
+	VpnForwardListening:  "Port forward",
+	VpnForwardInvalid:    "Invalid --forward value [%s], use localPort:host:remotePort, e.g. 8080:app.zerops:80",
+
+	VpnWatching:               "Watching the VPN connection, press Ctrl+C to disconnect",
+	VpnWatchRecovered:         "VPN connection recovered",
+	VpnWatchReconnecting:      "VPN connection is stale (%s), reconnecting",
+	VpnWatchInterfaceMissing:  "interface is missing",
+	VpnWatchHandshakeTooOld:   "last handshake %s ago",
+	VpnWatchProbesFailed:      "check targets are unreachable",
+	VpnWatcherStarted:         "VPN watcher started in background",
+	VpnWatcherStopFailed:      "VPN watcher [pid %d] did not stop in time",
+	VpnWatchIntervalInvalid:   "Invalid --watchInterval value. Use a positive duration, e.g. 15s.",
+	VpnBackgroundWithoutWatch: "--background can be used only together with --watch",
+	VpnDnsModeInvalid:         "Invalid --dns value. Allowed values are none, auto, resolvectl, resolvconf, networkmanager and hosts.",
+	VpnDnsModeUnsupported:     "--dns %s is supported only on linux",
+	VpnDnsToolMissing:         "%s is not installed, it is required by --dns %s",
+	VpnDnsHostsWriteFailed:    "Unable to update %s, run the command with root privileges or use --dns none",
+	VpnDnsHostsResolveFailed:  "Unable to resolve [%s] through the VPN, it won't be in the hosts file: %s",
+	VpnDnsResolvconfGlobal:    "--dns resolvconf makes the project DNS server the only DNS server of the system, all domains are resolved through the VPN until it is disconnected",
+
 	// vpn down
 	CmdHelpVpnDown: "the vpn down command.",
 	CmdDescVpnDown: "Disconnects from the Zerops VPN.",
 	VpnDown:        "VPN disconnected",
 
+	// vpn status
+	CmdHelpVpnStatus:          "the vpn status command.",
+	CmdDescVpnStatus:          "Shows VPN tunnels connected by zCLI with handshake, transfer and latency diagnostics.",
+	VpnStatusDeviceUnreadable: "Unable to read the WireGuard device %s, run the command with root privileges for details: %s",
+	VpnStatusResolverOk:       "ok",
+	VpnStatusResolverFailed:   "zerops domain is not resolved",
+	VpnStatusNoHandshake:      "interface is up, no recent handshake",
+	VpnStatusHandshakeAgo:     "%s ago",
+
+	// vpn key
+	CmdHelpVpnKey:              "the vpn key command.",
+	CmdDescVpnKey:              "VPN keys commands group",
+	CmdHelpVpnKeyList:          "the vpn key list command.",
+	CmdDescVpnKeyList:          "Lists stored VPN keys with their age and public keys.",
+	CmdHelpVpnKeyRotate:        "the vpn key rotate command.",
+	CmdDescVpnKeyRotate:        "Replaces the VPN key of a project with a new one, an active tunnel is reconnected.",
+	CmdHelpVpnKeyDelete:        "the vpn key delete command.",
+	CmdDescVpnKeyDelete:        "Removes stored VPN keys and their peers from projects.",
+	VpnKeyNoKeys:               "There are no stored VPN keys",
+	VpnKeyCorrupted:            "corrupted",
+	VpnKeyExpired:              "VPN key is older than %s, a new one will be created",
+	VpnKeyRotated:              "VPN key rotated, new public key",
+	VpnKeyDeregisterFailed:     "Unable to remove the previous VPN key from the project: %s",
+	VpnKeyDeleteConfirm:        "%d VPN key(s) will be deleted, do you want to continue?",
+	VpnKeyDeleteProjectMissing: "Choose a project of the key to delete or use --all",
+	VpnKeyNotFound:             "There is no VPN key of the project [%s]",
+	VpnKeyDeleted:              "VPN key deleted",
+	VpnMaxKeyAgeInvalid:        "Invalid --maxKeyAge value. The duration must not be negative, e.g. 720h.",
+
+	// vpn config
+	CmdHelpVpnConfig:       "the vpn config command.",
+	CmdDescVpnConfig:       "VPN config commands group",
+	CmdHelpVpnConfigExport: "the vpn config export command.",
+	CmdDescVpnConfigExport: "Registers a new VPN key and exports the WireGuard config for machines with their own WireGuard setup.",
+	VpnConfigExported:      "VPN config exported",
+	VpnConfigPublicKey:     "Public key of the exported config",
+
+	// vpn doctor
+	CmdHelpVpnDoctor:               "the vpn doctor command.",
+	CmdDescVpnDoctor:               "Checks the local VPN setup and suggests how to fix found problems.",
+	VpnDoctorHint:                  "Run 'zcli vpn doctor' to check the local VPN setup.",
+	VpnDoctorFix:                   "fix",
+	VpnDoctorFailed:                "%d check(s) failed",
+	VpnDoctorOk:                    "The VPN setup is ok",
+	VpnDoctorWireguard:             "WireGuard",
+	VpnDoctorInstalled:             "installed",
+	VpnDoctorNotInstalled:          "not installed",
+	VpnDoctorPrivileges:            "Privileges",
+	VpnDoctorPrivileged:            "running with administrator privileges",
+	VpnDoctorNotPrivileged:         "creating the tunnel needs administrator privileges, or use 'zcli vpn up --userspace'",
+	VpnDoctorFixPrivileges:         "sudo zcli vpn up",
+	VpnDoctorFixPrivilegesWindows:  "run the terminal as Administrator",
+	VpnDoctorInterfaces:            "Interfaces",
+	VpnDoctorNoInterfaces:          "no zerops tunnel is active",
+	VpnDoctorInterface:             "Interface %s",
+	VpnDoctorTrackedInterface:      "connected by zCLI",
+	VpnDoctorLegacyInterface:       "the tunnel of an older zCLI version is active, its routes clash with new tunnels",
+	VpnDoctorUntrackedInterface:    "the tunnel was not connected by zCLI, its routes may clash with new tunnels",
+	VpnDoctorResolver:              "Resolver",
+	VpnDoctorResolverHosts:         "%s has no per-domain routing, project services are written into the hosts file",
+	VpnDoctorFixResolver:           "install systemd-resolved or NetworkManager, services created later are resolved after the next vpn up",
+	VpnDoctorConfigFile:            "Config file",
+	VpnDoctorConfigFileMode:        "%s is readable by other users (%s), it contains the private key",
+	VpnDoctorConfigFileNotWritable: "no location of the config file is writable: %s",
+	VpnDoctorFixConfigFile:         "set %s to a writable file path",
+	VpnDoctorEndpoint:              "Endpoint",
+	VpnDoctorEndpointSkipped:       "Choose a project to check its VPN endpoint, e.g. zcli vpn doctor <projectId>",
+	VpnDoctorEndpointNoHandshake:   "no handshake with %s",
+	VpnDoctorFixEndpoint:           "allow outgoing UDP traffic to the endpoint in the firewall, WireGuard doesn't work through HTTP proxies",
+
 	// vpn shared
 	VpnWgQuickIsNotInstalled:        "wg-quick is not installed, please visit https://www.wireguard.com/install/",
 	VpnWgQuickIsNotInstalledWindows: "wireguard is not installed, please visit https://www.wireguard.com/install/",
+	VpnNoActiveTunnel:               "There is no active VPN tunnel",
+	VpnTunnelNotFound:               "There is no VPN tunnel of the project [%s]",
+	VpnMultipleTunnels:              "Several VPN tunnels are active [%s], choose one of the projects or use --all",
 
 	// flags description
-	RegionFlag:            "Choose one of Zerops regions. Use the \"zcli region list\" command to list all Zerops regions.",
-	RegionUrlFlag:         "Zerops region file url.",
-	BuildVersionName:      "Adds a custom version name. Automatically filled if the VERSIONNAME environment variable exists.",
-	BuildWorkingDir:       "Sets a custom working directory. Default working directory is the current directory.",
-	BuildArchiveFilePath:  "If set, zCLI creates a tar.gz archive with the application code in the required path relative\nto the working directory. By default, no archive is created.",
-	ZeropsYamlLocation:    "Sets a custom path to the zerops.yml file relative to the working directory. By default zCLI\nlooks for zerops.yml in the working directory.",
-	UploadGitFolder:       "If set, zCLI the .git folder is also uploaded. By default, the .git folder is ignored.",
-	OrgIdFlag:             "If you have access to more than one organization, you must specify the org ID for which the\nproject is to be created.",
-	LogLimitFlag:          "How many of the most recent log messages will be returned. Allowed interval is <1;1000>.\nDefault value = 100.",
-	LogMinSeverityFlag:    "Returns log messages with requested or higher severity. Set either severity number in the interval\n<0;7> or one of following severity codes:\nEMERGENCY, ALERT, CRITICAL, ERROR, WARNING, NOTICE, INFORMATIONAL, DEBUG.",
-	LogMsgTypeFlag:        "Select either APPLICATION or WEBSERVER log messages to be returned. Default value = APPLICATION.",
-	LogShowBuildFlag:      "If set, zCLI will return build log messages instead of runtime log messages.",
-	LogFollowFlag:         "If set, zCLI will continuously poll for new log messages. By default, the command will exit\nonce there are no more logs to display. To exit from this mode, use Control-C.",
-	LogFormatFlag:         "The format of returned log messages. Following formats are supported: \nFULL: This is the default format. Messages will be returned in the complete Syslog format. \nSHORT: Returns only timestamp and log message.\nJSON: Messages will be returned as one JSON object.\nJSONSTREAM: Messages will be returned as stream of JSON objects.",
-	LogFormatTemplateFlag: "Set a custom log format. Can be used only with --format=FULL.\nExample: --formatTemplate=\"{{.timestamp}} {{.severity}} {{.facility}} {{.message}}\".\nSupports standard GoLang template format and functions.",
+	RegionFlag:           "Choose one of Zerops regions. Use the \"zcli region list\" command to list all Zerops regions.",
+	RegionUrlFlag:        "Zerops region file url.",
+	BuildVersionName:     "Adds a custom version name. Automatically filled if the VERSIONNAME environment variable exists.",
+	BuildWorkingDir:      "Sets a custom working directory. Default working directory is the current directory.",
+	BuildArchiveFilePath: "If set, zCLI creates a tar.gz archive with the application code in the required path relative\nto the working directory. By default, no archive is created.",
+	ZeropsYamlLocation:   "Sets a custom path to the zerops.yml file relative to the working directory. By default zCLI\nlooks for zerops.yml in the working directory.",
+	UploadGitFolder:      "If set, zCLI the .git folder is also uploaded. By default, the .git folder is ignored.",
+	OrgIdFlag:            "If you have access to more than one organization, you must specify the org ID for which the\nproject is to be created.",
+	LogLimitFlag:         "How many of the most recent log messages will be returned. Allowed interval is <1;1000>,\nwith --since it caps all messages of the time window. Default value = 100.",
+	LogMinSeverityFlag:   "Returns log messages with requested or higher severity. Set either severity number in the interval\n<0;7> or one of following severity codes:\nEMERGENCY, ALERT, CRITICAL, ERROR, WARNING, NOTICE, INFORMATIONAL, DEBUG.",
+	LogMsgTypeFlag:       "Select either APPLICATION or WEBSERVER log messages to be returned. Default value = APPLICATION.",
+	LogShowBuildFlag:     "If set, zCLI will return build log messages instead of runtime log messages.",
+	LogFollowFlag:        "If set, zCLI will continuously poll for new log messages. By default, the command will exit\nonce there are no more logs to display. To exit from this mode, use Control-C.",
+	LogFormatFlag:        "The format of returned log messages. Following formats are supported: \nPRETTY: This is the default format in a terminal. Messages will be returned with a local time, colored severity and hostname.\nFULL: This is the default format outside of a terminal. Messages will be returned in the complete Syslog format. \nSHORT: Returns only timestamp and log message.\nJSON: Messages will be returned as one JSON object. With --follow, one JSON object per line is returned (NDJSON).\nJSONSTREAM: Messages will be returned as stream of JSON objects.",
+	LogFormatTemplateFlag: "Set a custom log format. Can be used only with --format=FULL.\nExample: --formatTemplate=\"{{.timestamp}} {{.severity}} {{.facility}} {{.message}}\".\nSupports standard GoLang template format and functions, plus following helpers:\n" +
+		"color \"red\" .message, pad 10 .hostname, time \"15:04\" .timestamp, json ., severityColor .severity .severityLabel.",
+	LogSinceFlag: "Returns log messages from the given time window, e.g. 30m or 24h. The log is paged through\nuntil the window is exhausted or --limit messages are returned.",
+	LogOutputFlag: "Writes log messages to a file instead of stdout. Files ending with .gz are gzip compressed.\n" +
+		"Use syslog://host:port or syslog+tcp://host:port to forward messages to a syslog server.",
+	LogRotateSizeFlag:     "Rotates the --output file in --follow mode once it reaches the given size in megabytes (MB), e.g. 100.",
+	LogRotateIntervalFlag: "Rotates the --output file in --follow mode after the given duration, e.g. 1h.",
+	LogOnMatchFlag:        "Fires the --exec or --webhook hook for log messages matching the regular expression.",
+	LogAlertExecFlag:      "A shell command executed for matching log messages. The message is passed as JSON on stdin.",
+	LogAlertWebhookFlag:   "An URL where matching log messages are sent as JSON in a POST request.",
+	LogAlertSeverityFlag:  "Fires hooks only for log messages with the given or higher severity, e.g. ERROR.",
+	LogAlertRateLimitFlag: "Minimal interval between two fired hooks, e.g. 10s.",
+	LogAlertDedupFlag:     "The same log message fires a hook only once within the given window, e.g. 5m.",
 	ConfirmFlag:           "If set, zCLI will not ask for confirmation of destructive operations.",
 	ServiceIdFlag:         "If you have access to more than one service, you must specify the service ID for which the\ncommand is to be executed.",
 	ProjectIdFlag:         "If you have access to more than one project, you must specify the project ID for which the\ncommand is to be executed.",
 	VpnAutoDisconnectFlag: "If set, zCLI will automatically disconnect from the VPN if it is already connected.",
-	ZeropsYamlSetup:       "Choose setup to be used from zerops.yml.",
+	VpnUserspaceFlag:      "If set, zCLI runs the VPN inside its own process without root privileges or wg-quick.\nThe project network is reachable through a local SOCKS5/HTTP proxy and --forward ports.",
+	VpnProxyAddressFlag:   "Local address of the SOCKS5/HTTP proxy in --userspace mode.",
+	VpnForwardFlag:        "Comma separated local port forwards in --userspace mode, e.g. 8080:app.zerops:80,5432:db.zerops:5432.",
+	VpnMtuFlag:            "MTU of the --userspace VPN interface.",
+	VpnCheckTargetsFlag:   "Comma separated targets checked after the VPN is connected: icmp:host, tcp:host:port or dns:host[@server].",
+	VpnDownAllFlag:        "If set, zCLI disconnects all VPN tunnels.",
+	VpnStatusFormatFlag:   "The output format, table or json.",
+	VpnMaxKeyAgeFlag:      "VPN keys older than the given duration, e.g. 720h, are rotated automatically. By default keys are never rotated.",
+	VpnKeyDeleteAllFlag:   "If set, zCLI deletes all stored VPN keys.",
+	VpnWatchFlag:          "If set, zCLI keeps watching the VPN handshake and check targets and reconnects a stale tunnel.\nUse Control-C to stop watching and disconnect.",
+	VpnWatchIntervalFlag:  "How often the VPN connection is checked in --watch mode, e.g. 15s.",
+	VpnBackgroundFlag:     "If set, the --watch mode runs in a background process, it is stopped by the 'zcli vpn down' command.",
+	VpnDnsFlag: "How the zerops domain is resolved through the VPN:\n" +
+		"auto: detected from the system resolver, systemd-resolved, NetworkManager or the hosts file as a fallback.\n" +
+		"resolvectl: the tunnel interface gets the project DNS server for the zerops domain by systemd-resolved.\n" +
+		"resolvconf: the project DNS server is registered by resolvconf, it resolves all domains while the VPN is connected.\n" +
+		"networkmanager: the tunnel device gets the project DNS server for the zerops domain by nmcli.\n" +
+		"hosts: project services are written into the hosts file until the VPN is disconnected.\n" +
+		"none: DNS is not configured.",
+	VpnConfigFormatFlag: "The format of the exported config, wg-quick, networkmanager or json.",
+	VpnConfigOutputFlag: "Writes the config to a file instead of stdout.",
+	VpnConfigDnsFlag:    "How the zerops domain is resolved by the wg-quick config, see the --dns flag of vpn up. By default DNS is left to the target machine.",
+	ZeropsYamlSetup:     "Choose setup to be used from zerops.yml.",
 
 	// archiveClient
 	ArchClientWorkingDirectory:  "working directory: %s",
@@ -227,9 +470,13 @@ var en = map[string]string{
 	// status info
 	StatusInfoCliDataFilePath:        "Zerops CLI data file path",
 	StatusInfoLogFilePath:            "Zerops CLI log file path",
-	StatusInfoWgConfigFilePath:       "Zerops CLI wg config file path",
 	StatusInfoLoggedUser:             "Logged user",
+	StatusInfoContext:                "Context",
+	StatusInfoToken:                  "Token",
+	StatusInfoTokenFromEnv:           "from the ZEROPS_TOKEN env variable, not stored",
+	StatusInfoTokenStored:            "stored",
 	StatusInfoVpnStatus:              "VPN status",
+	StatusInfoVpnTunnel:              "VPN tunnel %s",
 	VpnCheckingConnectionIsActive:    "VPN connection is active",
 	VpnCheckingConnectionIsNotActive: "VPN connection is not active",
 
@@ -238,14 +485,32 @@ var en = map[string]string{
 	// //////////
 	ProcessInvalidState: "last command has finished with error, identifier for communication with our support: %s",
 
-	CliTerminalModeEnvVar: "If enabled provides a rich UI to communicate with a user. Possible values: auto, enabled, disabled. Default value is auto.",
-	CliLogFilePathEnvVar:  "Path to a log file.",
-	CliDataFilePathEnvVar: "Path to data file.",
-
-	UnknownTerminalMode:    "Unknown terminal mode: %s. Falling back to auto-discovery. Possible values: auto, enabled, disabled.",
-	UnableToDecodeJsonFile: "Unable to decode json file: %s",
-	UnableToWriteCliData:   "Unable to write zcli data, paths tested: %s",
-	UnableToWriteLogFile:   "Unable to write zcli debug log file, paths tested: %s",
+	CliTerminalModeEnvVar:     "If enabled provides a rich UI to communicate with a user. Possible values: auto, enabled, disabled. Default value is auto.",
+	CliLogFilePathEnvVar:      "Path to a log file.",
+	CliDataFilePathEnvVar:     "Path to data file.",
+	CliSecretStoreEnvVar:      "Where the token and VPN keys are kept. Possible values: auto, keyring, kernel, file, plain. Default value is auto.",
+	CliSecretPassphraseEnvVar: "Passphrase of the encrypted secret file, it is used when no OS keyring is available.",
+	CliContextEnvVar:          "Login context used instead of the current one.",
+	CliTokenEnvVar:            "Token used instead of the stored one, it is never stored. Useful in CI.",
+	CliRegionEnvVar:           "Region of the ZEROPS_TOKEN token. Default value is the default region.",
+	CliConfigFilePathEnvVar:   "Path to the user config file.",
+
+	UnknownTerminalMode:           "Unknown terminal mode: %s. Falling back to auto-discovery. Possible values: auto, enabled, disabled.",
+	UnableToDecodeJsonFile:        "Unable to decode json file: %s",
+	UnableToWriteCliData:          "Unable to write zcli data, paths tested: %s",
+	UnableToLockCliData:           "Unable to lock %s, another zcli process is holding it",
+	UnableToFindConfigFile:        "Unable to find the config file location, paths tested: %s",
+	CliDataNewerVersion:           "%s was written by a newer zcli (schema version %d, this zcli supports %d), update zcli or set ZEROPS_CLI_DATA_FILE_PATH to another file",
+	CliDataMigrationFailed:        "Unable to migrate %s to the schema version %d, the file is left unchanged",
+	UnableToWriteLogFile:          "Unable to write zcli debug log file, paths tested: %s",
+	UnableToReadSecrets:           "Unable to read secrets from the %s store of %s, set ZEROPS_SECRET_STORE to another store or remove the file and log in again",
+	SecretMissing:                 "secret %s is missing in the %s store",
+	SecretStoreKeyringUnavailable: "The OS keyring is not available",
+	SecretStoreKernelUnavailable:  "The linux kernel keyring is not available",
+	SecretStoreTempDirRefused:     "The token and VPN keys would be stored in plaintext in %s in the temp directory, set %s to a persistent file or %s to keep them in an encrypted file",
+	SecretStorePassphraseMissing:  "The encrypted secret file needs a passphrase in the %s env variable",
+	SecretStoreUnknown:            "Unknown secret store: %s. Possible values: auto, keyring, kernel, file, plain.",
+	SecretStoreDecryptFailed:      "Unable to decrypt %s, check the passphrase",
 
 	// args
 	ArgsOnlyOneOptionalAllowed: "optional arg %s can be only the last one",
@@ -253,6 +518,10 @@ var en = map[string]string{
 	ArgsNotEnoughRequiredArgs:  "expected at least %d arg(s), got %d",
 	ArgsTooManyArgs:            "expected no more than %d arg(s), got %d",
 
+	// flags
+	FlagValueInvalid:    "invalid value %q of the %s flag: %s",
+	FlagValueNotAllowed: "invalid value %q of the %s flag, allowed values are %s",
+
 	// ux helpers
 	ProjectSelectorListEmpty:       "You don't have any projects yet. Create a new project using `zcli project import` command.",
 	ProjectSelectorPrompt:          "Please, select a project",
@@ -20,9 +20,58 @@ const (
 	CmdHelpLogin          = "CmdHelpLogin"
 	CmdDescLogin          = "CmdDescLogin"
 	LoginSuccess          = "LoginSuccess"
+	LoginTokenStdinFlag   = "LoginTokenStdinFlag"
+	LoginTokenMissing     = "LoginTokenMissing"
+	LoginTokenTwice       = "LoginTokenTwice"
 	RegionNotFound        = "RegionNotFound"
 	RegionTableColumnName = "RegionTableColumnName"
 
+	// config
+	CmdHelpConfig        = "CmdHelpConfig"
+	CmdDescConfig        = "CmdDescConfig"
+	CmdHelpConfigGet     = "CmdHelpConfigGet"
+	CmdDescConfigGet     = "CmdDescConfigGet"
+	CmdHelpConfigSet     = "CmdHelpConfigSet"
+	CmdDescConfigSet     = "CmdDescConfigSet"
+	CmdHelpConfigUnset   = "CmdHelpConfigUnset"
+	CmdDescConfigUnset   = "CmdDescConfigUnset"
+	CmdHelpConfigList    = "CmdHelpConfigList"
+	CmdDescConfigList    = "CmdDescConfigList"
+	CmdHelpConfigPath    = "CmdHelpConfigPath"
+	CmdDescConfigPath    = "CmdDescConfigPath"
+	ConfigProjectFlag    = "ConfigProjectFlag"
+	ConfigShowOriginFlag = "ConfigShowOriginFlag"
+	ConfigValueSet       = "ConfigValueSet"
+	ConfigValueUnset     = "ConfigValueUnset"
+	ConfigKeyNotSet      = "ConfigKeyNotSet"
+	ConfigNoValues       = "ConfigNoValues"
+	ConfigOriginEnv      = "ConfigOriginEnv"
+	ConfigFileInvalid    = "ConfigFileInvalid"
+	ConfigPathUser       = "ConfigPathUser"
+	ConfigPathProject    = "ConfigPathProject"
+
+	// region
+	CmdHelpRegion      = "CmdHelpRegion"
+	CmdDescRegion      = "CmdDescRegion"
+	CmdHelpRegionList  = "CmdHelpRegionList"
+	CmdDescRegionList  = "CmdDescRegionList"
+	CmdHelpRegionUse   = "CmdHelpRegionUse"
+	CmdDescRegionUse   = "CmdDescRegionUse"
+	RegionSwitched     = "RegionSwitched"
+	RegionVpnTunnels   = "RegionVpnTunnels"
+	RegionTokenInvalid = "RegionTokenInvalid"
+
+	// logout
+	CmdHelpLogout            = "CmdHelpLogout"
+	CmdDescLogout            = "CmdDescLogout"
+	LogoutAllFlag            = "LogoutAllFlag"
+	LogoutRevokeFlag         = "LogoutRevokeFlag"
+	LogoutSuccess            = "LogoutSuccess"
+	LogoutNotLoggedIn        = "LogoutNotLoggedIn"
+	LogoutEnvToken           = "LogoutEnvToken"
+	LogoutRevokeFailed       = "LogoutRevokeFailed"
+	LogoutVpnKeyDeleteFailed = "LogoutVpnKeyDeleteFailed"
+
 	// scope
 	CmdHelpScope = "CmdHelpScope"
 	CmdDescScope = "CmdDescScope"
@@ -81,6 +130,12 @@ const (
 	ServiceStopFailed  = "ServiceStopFailed"
 	ServiceStopped     = "ServiceStopped"
 
+	// service port-forward
+	CmdHelpServicePortForward = "CmdHelpServicePortForward"
+	CmdDescServicePortForward = "CmdDescServicePortForward"
+	ServicePortForwardInvalid = "ServicePortForwardInvalid"
+	ServicePortForwardRunning = "ServicePortForwardRunning"
+
 	// service delete
 	CmdHelpServiceDelete = "CmdHelpServiceDelete"
 	CmdDescServiceDelete = "CmdDescServiceDelete"
@@ -89,23 +144,34 @@ const (
 	ServiceDeleted       = "ServiceDeleted"
 
 	// service log
-	CmdHelpServiceLog            = "CmdHelpServiceLog"
-	CmdDescServiceLog            = "CmdDescServiceLog"
-	CmdDescServiceLogLong        = "CmdDescServiceLogLong"
-	LogLimitInvalid              = "LogLimitInvalid"
-	LogMinSeverityInvalid        = "LogMinSeverityInvalid"
-	LogMinSeverityStringLimitErr = "LogMinSeverityStringLimitErr"
-	LogMinSeverityNumLimitErr    = "LogMinSeverityNumLimitErr"
-	LogFormatInvalid             = "LogFormatInvalid"
-	LogFormatTemplateMismatch    = "LogFormatTemplateMismatch"
-	LogFormatStreamMismatch      = "LogFormatStreamMismatch"
-	LogFormatTemplateInvalid     = "LogFormatTemplateInvalid"
-	LogFormatTemplateNoSpace     = "LogFormatTemplateNoSpace"
-	LogNoBuildFound              = "LogNoBuildFound"
-	LogBuildStatusUploading      = "LogBuildStatusUploading"
-	LogAccessFailed              = "LogAccessFailed"
-	LogMsgTypeInvalid            = "LogMsgTypeInvalid"
-	LogReadingFailed             = "LogReadingFailed"
+	CmdHelpServiceLog                 = "CmdHelpServiceLog"
+	CmdDescServiceLog                 = "CmdDescServiceLog"
+	CmdDescServiceLogLong             = "CmdDescServiceLogLong"
+	LogLimitInvalid                   = "LogLimitInvalid"
+	LogMinSeverityInvalid             = "LogMinSeverityInvalid"
+	LogMinSeverityStringLimitErr      = "LogMinSeverityStringLimitErr"
+	LogMinSeverityNumLimitErr         = "LogMinSeverityNumLimitErr"
+	LogFormatTemplateMismatch         = "LogFormatTemplateMismatch"
+	LogFormatTemplateInvalid          = "LogFormatTemplateInvalid"
+	LogNoBuildFound                   = "LogNoBuildFound"
+	LogBuildStatusUploading           = "LogBuildStatusUploading"
+	LogAccessFailed                   = "LogAccessFailed"
+	LogReadingFailed                  = "LogReadingFailed"
+	LogStreamReconnecting             = "LogStreamReconnecting"
+	LogStreamReconnectBudgetExhausted = "LogStreamReconnectBudgetExhausted"
+	LogStreamPossibleGap              = "LogStreamPossibleGap"
+	LogSinceInvalid                   = "LogSinceInvalid"
+	LogSinceIncomplete                = "LogSinceIncomplete"
+	LogSinceFollowMismatch            = "LogSinceFollowMismatch"
+	LogRotateMismatch                 = "LogRotateMismatch"
+	LogRotateSizeInvalid              = "LogRotateSizeInvalid"
+	LogRotateIntervalInvalid          = "LogRotateIntervalInvalid"
+	LogAlertHookMissing               = "LogAlertHookMissing"
+	LogAlertPatternInvalid            = "LogAlertPatternInvalid"
+	LogAlertSeverityInvalid           = "LogAlertSeverityInvalid"
+	LogAlertRateLimitInvalid          = "LogAlertRateLimitInvalid"
+	LogAlertDedupWindowInvalid        = "LogAlertDedupWindowInvalid"
+	LogAlertHookFailed                = "LogAlertHookFailed"
 
 	// service deploy
 	CmdHelpServiceDeploy = "CmdHelpServiceDeploy"
@@ -153,30 +219,168 @@ const (
 	CmdHelpVersion = "CmdHelpVersion"
 	CmdDescVersion = "CmdDescVersion"
 
+	// completion
+	CmdHelpCompletion      = "CmdHelpCompletion"
+	CmdDescCompletion      = "CmdDescCompletion"
+	CmdDescCompletionLong  = "CmdDescCompletionLong"
+	CompletionShellUnknown = "CompletionShellUnknown"
+
+	// auth
+	CmdHelpAuth              = "CmdHelpAuth"
+	CmdDescAuth              = "CmdDescAuth"
+	CmdHelpAuthStatus        = "CmdHelpAuthStatus"
+	CmdDescAuthStatus        = "CmdDescAuthStatus"
+	AuthStatusLoggedIn       = "AuthStatusLoggedIn"
+	AuthStatusYes            = "AuthStatusYes"
+	AuthStatusNo             = "AuthStatusNo"
+	AuthStatusRegion         = "AuthStatusRegion"
+	AuthStatusSecrets        = "AuthStatusSecrets"
+	AuthStatusSecretsKeyring = "AuthStatusSecretsKeyring"
+	AuthStatusSecretsKernel  = "AuthStatusSecretsKernel"
+	AuthStatusSecretsFile    = "AuthStatusSecretsFile"
+	AuthStatusSecretsPlain   = "AuthStatusSecretsPlain"
+	AuthStatusVpnKeys        = "AuthStatusVpnKeys"
+	AuthStatusPlainWarning   = "AuthStatusPlainWarning"
+
+	// context
+	CmdHelpContext        = "CmdHelpContext"
+	CmdDescContext        = "CmdDescContext"
+	CmdHelpContextAdd     = "CmdHelpContextAdd"
+	CmdDescContextAdd     = "CmdDescContextAdd"
+	CmdHelpContextUse     = "CmdHelpContextUse"
+	CmdDescContextUse     = "CmdDescContextUse"
+	CmdHelpContextList    = "CmdHelpContextList"
+	CmdDescContextList    = "CmdDescContextList"
+	CmdHelpContextCurrent = "CmdHelpContextCurrent"
+	CmdDescContextCurrent = "CmdDescContextCurrent"
+	ContextFlag           = "ContextFlag"
+	ContextAdded          = "ContextAdded"
+	ContextSwitched       = "ContextSwitched"
+	ContextNotFound       = "ContextNotFound"
+	ContextAlreadyExists  = "ContextAlreadyExists"
+	ContextInvalidName    = "ContextInvalidName"
+	ContextNotLoggedIn    = "ContextNotLoggedIn"
+
 	// vpn
 	CmdHelpVpn = "CmdHelpVpn"
 	CmdDescVpn = "CmdDescVpn"
 
 	// vpn up
-	CmdHelpVpnUp             = "CmdHelpVpnUp"
-	CmdDescVpnUp             = "CmdDescVpnUp"
-	VpnUp                    = "VpnUp"
-	VpnConfigSaved           = "VpnConfigSaved"
-	VpnPrivateKeyCorrupted   = "VpnPrivateKeyCorrupted"
-	VpnPrivateKeyCreated     = "VpnPrivateKeyCreated"
-	VpnDisconnectionPrompt   = "VpnDisconnectionPrompt"
-	VpnDisconnectionPromptNo = "VpnDisconnectionPromptNo"
-	VpnCheckingConnection    = "VpnCheckingConnection"
-	VpnPingFailed            = "VpnPingFailed"
+	CmdHelpVpnUp              = "CmdHelpVpnUp"
+	CmdDescVpnUp              = "CmdDescVpnUp"
+	VpnUp                     = "VpnUp"
+	VpnConfigSaved            = "VpnConfigSaved"
+	VpnConfigEnvDeprecated    = "VpnConfigEnvDeprecated"
+	VpnPrivateKeyCorrupted    = "VpnPrivateKeyCorrupted"
+	VpnPrivateKeyCreated      = "VpnPrivateKeyCreated"
+	VpnDisconnectionPrompt    = "VpnDisconnectionPrompt"
+	VpnDisconnectionPromptNo  = "VpnDisconnectionPromptNo"
+	VpnCheckingConnection     = "VpnCheckingConnection"
+	VpnPingFailed             = "VpnPingFailed"
+	VpnUserspaceUp            = "VpnUserspaceUp"
+	VpnProxyListening         = "VpnProxyListening"
+	VpnProxyListenFailed      = "VpnProxyListenFailed"
+	VpnForwardListening       = "VpnForwardListening"
+	VpnForwardInvalid         = "VpnForwardInvalid"
+	VpnCheckTargetsInvalid    = "VpnCheckTargetsInvalid"
+	VpnWatching               = "VpnWatching"
+	VpnWatchRecovered         = "VpnWatchRecovered"
+	VpnWatchReconnecting      = "VpnWatchReconnecting"
+	VpnWatchInterfaceMissing  = "VpnWatchInterfaceMissing"
+	VpnWatchHandshakeTooOld   = "VpnWatchHandshakeTooOld"
+	VpnWatchProbesFailed      = "VpnWatchProbesFailed"
+	VpnWatcherStarted         = "VpnWatcherStarted"
+	VpnWatcherStopFailed      = "VpnWatcherStopFailed"
+	VpnWatchIntervalInvalid   = "VpnWatchIntervalInvalid"
+	VpnBackgroundWithoutWatch = "VpnBackgroundWithoutWatch"
+	VpnDnsModeInvalid         = "VpnDnsModeInvalid"
+	VpnDnsModeUnsupported     = "VpnDnsModeUnsupported"
+	VpnDnsToolMissing         = "VpnDnsToolMissing"
+	VpnDnsHostsWriteFailed    = "VpnDnsHostsWriteFailed"
+	VpnDnsHostsResolveFailed  = "VpnDnsHostsResolveFailed"
+	VpnDnsResolvconfGlobal    = "VpnDnsResolvconfGlobal"
 
 	// vpn down
 	CmdHelpVpnDown = "CmdHelpVpnDown"
 	CmdDescVpnDown = "CmdDescVpnDown"
 	VpnDown        = "VpnDown"
 
+	// vpn status
+	CmdHelpVpnStatus          = "CmdHelpVpnStatus"
+	CmdDescVpnStatus          = "CmdDescVpnStatus"
+	VpnStatusDeviceUnreadable = "VpnStatusDeviceUnreadable"
+	VpnStatusResolverOk       = "VpnStatusResolverOk"
+	VpnStatusResolverFailed   = "VpnStatusResolverFailed"
+	VpnStatusNoHandshake      = "VpnStatusNoHandshake"
+	VpnStatusHandshakeAgo     = "VpnStatusHandshakeAgo"
+
+	// vpn key
+	CmdHelpVpnKey              = "CmdHelpVpnKey"
+	CmdDescVpnKey              = "CmdDescVpnKey"
+	CmdHelpVpnKeyList          = "CmdHelpVpnKeyList"
+	CmdDescVpnKeyList          = "CmdDescVpnKeyList"
+	CmdHelpVpnKeyRotate        = "CmdHelpVpnKeyRotate"
+	CmdDescVpnKeyRotate        = "CmdDescVpnKeyRotate"
+	CmdHelpVpnKeyDelete        = "CmdHelpVpnKeyDelete"
+	CmdDescVpnKeyDelete        = "CmdDescVpnKeyDelete"
+	VpnKeyNoKeys               = "VpnKeyNoKeys"
+	VpnKeyCorrupted            = "VpnKeyCorrupted"
+	VpnKeyExpired              = "VpnKeyExpired"
+	VpnKeyRotated              = "VpnKeyRotated"
+	VpnKeyDeregisterFailed     = "VpnKeyDeregisterFailed"
+	VpnKeyDeleteConfirm        = "VpnKeyDeleteConfirm"
+	VpnKeyDeleteProjectMissing = "VpnKeyDeleteProjectMissing"
+	VpnKeyNotFound             = "VpnKeyNotFound"
+	VpnKeyDeleted              = "VpnKeyDeleted"
+	VpnMaxKeyAgeInvalid        = "VpnMaxKeyAgeInvalid"
+
+	// vpn config
+	CmdHelpVpnConfig       = "CmdHelpVpnConfig"
+	CmdDescVpnConfig       = "CmdDescVpnConfig"
+	CmdHelpVpnConfigExport = "CmdHelpVpnConfigExport"
+	CmdDescVpnConfigExport = "CmdDescVpnConfigExport"
+	VpnConfigExported      = "VpnConfigExported"
+	VpnConfigPublicKey     = "VpnConfigPublicKey"
+
+	// vpn doctor
+	CmdHelpVpnDoctor               = "CmdHelpVpnDoctor"
+	CmdDescVpnDoctor               = "CmdDescVpnDoctor"
+	VpnDoctorHint                  = "VpnDoctorHint"
+	VpnDoctorFix                   = "VpnDoctorFix"
+	VpnDoctorFailed                = "VpnDoctorFailed"
+	VpnDoctorOk                    = "VpnDoctorOk"
+	VpnDoctorWireguard             = "VpnDoctorWireguard"
+	VpnDoctorInstalled             = "VpnDoctorInstalled"
+	VpnDoctorNotInstalled          = "VpnDoctorNotInstalled"
+	VpnDoctorPrivileges            = "VpnDoctorPrivileges"
+	VpnDoctorPrivileged            = "VpnDoctorPrivileged"
+	VpnDoctorNotPrivileged         = "VpnDoctorNotPrivileged"
+	VpnDoctorFixPrivileges         = "VpnDoctorFixPrivileges"
+	VpnDoctorFixPrivilegesWindows  = "VpnDoctorFixPrivilegesWindows"
+	VpnDoctorInterfaces            = "VpnDoctorInterfaces"
+	VpnDoctorNoInterfaces          = "VpnDoctorNoInterfaces"
+	VpnDoctorInterface             = "VpnDoctorInterface"
+	VpnDoctorTrackedInterface      = "VpnDoctorTrackedInterface"
+	VpnDoctorLegacyInterface       = "VpnDoctorLegacyInterface"
+	VpnDoctorUntrackedInterface    = "VpnDoctorUntrackedInterface"
+	VpnDoctorResolver              = "VpnDoctorResolver"
+	VpnDoctorResolverHosts         = "VpnDoctorResolverHosts"
+	VpnDoctorFixResolver           = "VpnDoctorFixResolver"
+	VpnDoctorConfigFile            = "VpnDoctorConfigFile"
+	VpnDoctorConfigFileMode        = "VpnDoctorConfigFileMode"
+	VpnDoctorConfigFileNotWritable = "VpnDoctorConfigFileNotWritable"
+	VpnDoctorFixConfigFile         = "VpnDoctorFixConfigFile"
+	VpnDoctorEndpoint              = "VpnDoctorEndpoint"
+	VpnDoctorEndpointSkipped       = "VpnDoctorEndpointSkipped"
+	VpnDoctorEndpointNoHandshake   = "VpnDoctorEndpointNoHandshake"
+	VpnDoctorFixEndpoint           = "VpnDoctorFixEndpoint"
+
 	// vpn shared
 	VpnWgQuickIsNotInstalled        = "VpnWgQuickIsNotInstalled"
 	VpnWgQuickIsNotInstalledWindows = "VpnWgQuickIsNotInstalledWindows"
+	VpnNoActiveTunnel               = "VpnNoActiveTunnel"
+	VpnTunnelNotFound               = "VpnTunnelNotFound"
+	VpnMultipleTunnels              = "VpnMultipleTunnels"
 
 	// flags description
 	RegionFlag            = "RegionFlag"
@@ -194,10 +398,36 @@ const (
 	LogShowBuildFlag      = "LogShowBuildFlag"
 	LogFormatFlag         = "LogFormatFlag"
 	LogFormatTemplateFlag = "LogFormatTemplateFlag"
+	LogSinceFlag          = "LogSinceFlag"
+	LogOutputFlag         = "LogOutputFlag"
+	LogRotateSizeFlag     = "LogRotateSizeFlag"
+	LogRotateIntervalFlag = "LogRotateIntervalFlag"
+	LogOnMatchFlag        = "LogOnMatchFlag"
+	LogAlertExecFlag      = "LogAlertExecFlag"
+	LogAlertWebhookFlag   = "LogAlertWebhookFlag"
+	LogAlertSeverityFlag  = "LogAlertSeverityFlag"
+	LogAlertRateLimitFlag = "LogAlertRateLimitFlag"
+	LogAlertDedupFlag     = "LogAlertDedupFlag"
 	ConfirmFlag           = "ConfirmFlag"
 	ServiceIdFlag         = "ServiceIdFlag"
 	ProjectIdFlag         = "ProjectIdFlag"
 	VpnAutoDisconnectFlag = "VpnAutoDisconnectFlag"
+	VpnUserspaceFlag      = "VpnUserspaceFlag"
+	VpnProxyAddressFlag   = "VpnProxyAddressFlag"
+	VpnForwardFlag        = "VpnForwardFlag"
+	VpnMtuFlag            = "VpnMtuFlag"
+	VpnCheckTargetsFlag   = "VpnCheckTargetsFlag"
+	VpnDownAllFlag        = "VpnDownAllFlag"
+	VpnStatusFormatFlag   = "VpnStatusFormatFlag"
+	VpnMaxKeyAgeFlag      = "VpnMaxKeyAgeFlag"
+	VpnKeyDeleteAllFlag   = "VpnKeyDeleteAllFlag"
+	VpnWatchFlag          = "VpnWatchFlag"
+	VpnWatchIntervalFlag  = "VpnWatchIntervalFlag"
+	VpnBackgroundFlag     = "VpnBackgroundFlag"
+	VpnDnsFlag            = "VpnDnsFlag"
+	VpnConfigFormatFlag   = "VpnConfigFormatFlag"
+	VpnConfigOutputFlag   = "VpnConfigOutputFlag"
+	VpnConfigDnsFlag      = "VpnConfigDnsFlag"
 	ZeropsYamlSetup       = "ZeropsYamlSetup"
 
 	// archiveClient
@@ -221,9 +451,13 @@ const (
 	// status info
 	StatusInfoCliDataFilePath        = "StatusInfoCliDataFilePath"
 	StatusInfoLogFilePath            = "StatusInfoLogFilePath"
-	StatusInfoWgConfigFilePath       = "StatusInfoWgConfigFilePath"
 	StatusInfoLoggedUser             = "StatusInfoLoggedUser"
+	StatusInfoContext                = "StatusInfoContext"
+	StatusInfoToken                  = "StatusInfoToken"
+	StatusInfoTokenFromEnv           = "StatusInfoTokenFromEnv"
+	StatusInfoTokenStored            = "StatusInfoTokenStored"
 	StatusInfoVpnStatus              = "StatusInfoVpnStatus"
+	StatusInfoVpnTunnel              = "StatusInfoVpnTunnel"
 	VpnCheckingConnectionIsActive    = "VpnCheckingConnectionIsActive"
 	VpnCheckingConnectionIsNotActive = "VpnCheckingConnectionIsNotActive"
 
@@ -232,14 +466,32 @@ const (
 	// //////////
 	ProcessInvalidState = "ProcessInvalidState"
 
-	CliTerminalModeEnvVar = "TerminalModeEnv"
-	CliLogFilePathEnvVar  = "CliLogFilePathEnvVar"
-	CliDataFilePathEnvVar = "CliDataFilePathEnvVar"
-
-	UnknownTerminalMode    = "UnknownTerminalMode"
-	UnableToDecodeJsonFile = "UnableToDecodeJsonFile"
-	UnableToWriteCliData   = "UnableToWriteCliData"
-	UnableToWriteLogFile   = "UnableToWriteLogFile"
+	CliTerminalModeEnvVar     = "TerminalModeEnv"
+	CliLogFilePathEnvVar      = "CliLogFilePathEnvVar"
+	CliDataFilePathEnvVar     = "CliDataFilePathEnvVar"
+	CliSecretStoreEnvVar      = "CliSecretStoreEnvVar"
+	CliSecretPassphraseEnvVar = "CliSecretPassphraseEnvVar"
+	CliContextEnvVar          = "CliContextEnvVar"
+	CliTokenEnvVar            = "CliTokenEnvVar"
+	CliRegionEnvVar           = "CliRegionEnvVar"
+	CliConfigFilePathEnvVar   = "CliConfigFilePathEnvVar"
+
+	UnknownTerminalMode           = "UnknownTerminalMode"
+	UnableToDecodeJsonFile        = "UnableToDecodeJsonFile"
+	UnableToWriteCliData          = "UnableToWriteCliData"
+	UnableToLockCliData           = "UnableToLockCliData"
+	UnableToFindConfigFile        = "UnableToFindConfigFile"
+	CliDataNewerVersion           = "CliDataNewerVersion"
+	CliDataMigrationFailed        = "CliDataMigrationFailed"
+	UnableToWriteLogFile          = "UnableToWriteLogFile"
+	UnableToReadSecrets           = "UnableToReadSecrets"
+	SecretMissing                 = "SecretMissing"
+	SecretStoreKeyringUnavailable = "SecretStoreKeyringUnavailable"
+	SecretStoreKernelUnavailable  = "SecretStoreKernelUnavailable"
+	SecretStoreTempDirRefused     = "SecretStoreTempDirRefused"
+	SecretStorePassphraseMissing  = "SecretStorePassphraseMissing"
+	SecretStoreUnknown            = "SecretStoreUnknown"
+	SecretStoreDecryptFailed      = "SecretStoreDecryptFailed"
 
 	// args
 	ArgsOnlyOneOptionalAllowed = "ArgsOnlyOneOptionalAllowed"
@@ -247,6 +499,10 @@ const (
 	ArgsNotEnoughRequiredArgs  = "ArgsNotEnoughRequiredArgs"
 	ArgsTooManyArgs            = "ArgsTooManyArgs"
 
+	// flags
+	FlagValueInvalid    = "FlagValueInvalid"
+	FlagValueNotAllowed = "FlagValueNotAllowed"
+
 	// ux helpers
 	ProjectSelectorListEmpty       = "ProjectSelectorListEmpty"
 	ProjectSelectorPrompt          = "ProjectSelectorPrompt"
@@ -0,0 +1,48 @@
+package nettools
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// ProbeDns resolves the host by the given DNS server, the system resolver is used if the server is empty
+func ProbeDns(ctx context.Context, server, host string) ProbeResult {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	result := ProbeResult{Kind: ProbeKindDns, Target: host}
+	if server != "" {
+		result.Target = host + "@" + server
+	}
+
+	start := time.Now()
+	addresses, err := LookupHost(ctx, server, host)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Rtt = time.Since(start)
+	result.Address = strings.Join(addresses, ", ")
+
+	return result
+}
+
+// LookupHost resolves the host by the given DNS server, the system resolver is used if the server is empty
+func LookupHost(ctx context.Context, server, host string) ([]string, error) {
+	resolver := net.DefaultResolver
+	if server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	return resolver.LookupHost(ctx, host)
+}
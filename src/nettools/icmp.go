@@ -0,0 +1,136 @@
+package nettools
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	protocolIcmp   = 1
+	protocolIcmpV6 = 58
+)
+
+// ProbeIcmp sends a single echo request to the host.
+// Unprivileged datagram ICMP sockets are tried first, raw sockets which need root are the fallback.
+func ProbeIcmp(ctx context.Context, host string) ProbeResult {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	result := ProbeResult{Kind: ProbeKindIcmp, Target: host}
+
+	ip, err := resolveIp(ctx, host)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Address = ip.String()
+
+	conn, unprivileged, err := listenIcmp(ip)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer conn.Close()
+
+	// stop the blocking read once the context is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	var echoType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	protocol := protocolIcmp
+	if ip.To4() == nil {
+		echoType, replyType, protocol = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply, protocolIcmpV6
+	}
+
+	// the kernel rewrites the echo ID of unprivileged sockets, replies are matched by the payload
+	payload := make([]byte, 16)
+	if _, err := rand.Read(payload); err != nil {
+		result.Err = errors.WithStack(err)
+		return result
+	}
+	request, err := (&icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: 1, Data: payload},
+	}).Marshal(nil)
+	if err != nil {
+		result.Err = errors.WithStack(err)
+		return result
+	}
+
+	var destination net.Addr = &net.IPAddr{IP: ip}
+	if unprivileged {
+		destination = &net.UDPAddr{IP: ip}
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(request, destination); err != nil {
+		result.Err = errors.WithStack(err)
+		return result
+	}
+
+	buffer := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			result.Err = errors.WithStack(err)
+			return result
+		}
+		message, err := icmp.ParseMessage(protocol, buffer[:n])
+		if err != nil || message.Type != replyType {
+			continue
+		}
+		if echo, ok := message.Body.(*icmp.Echo); ok && bytes.Equal(echo.Data, payload) {
+			result.Rtt = time.Since(start)
+			return result
+		}
+	}
+}
+
+func listenIcmp(ip net.IP) (*icmp.PacketConn, bool, error) {
+	udpNetwork, rawNetwork, address := "udp4", "ip4:icmp", "0.0.0.0"
+	if ip.To4() == nil {
+		udpNetwork, rawNetwork, address = "udp6", "ip6:ipv6-icmp", "::"
+	}
+
+	conn, err := icmp.ListenPacket(udpNetwork, address)
+	if err == nil {
+		return conn, true, nil
+	}
+	conn, rawErr := icmp.ListenPacket(rawNetwork, address)
+	if rawErr == nil {
+		return conn, false, nil
+	}
+	return nil, false, errors.Errorf("unable to open an ICMP socket: %s, %s", err, rawErr)
+}
+
+func resolveIp(ctx context.Context, host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	// IPv4 is preferred, it works with the unprivileged sockets on more systems
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip, nil
+		}
+	}
+	if len(ips) == 0 {
+		return nil, errors.Errorf("no address found for %s", host)
+	}
+	return ips[0], nil
+}
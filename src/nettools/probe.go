@@ -0,0 +1,119 @@
+package nettools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ProbeKind string
+
+const (
+	ProbeKindIcmp ProbeKind = "icmp"
+	ProbeKindTcp  ProbeKind = "tcp"
+	ProbeKindDns  ProbeKind = "dns"
+
+	// defaultProbeTimeout is used when the context has no deadline
+	defaultProbeTimeout = 2 * time.Second
+)
+
+// ProbeResult describes a single reachability check, Err is nil when the target answered
+type ProbeResult struct {
+	Kind   ProbeKind
+	Target string
+	// Address is the IP address or the list of addresses the target resolved to
+	Address string
+	Rtt     time.Duration
+	Err     error
+}
+
+func (r ProbeResult) Ok() bool {
+	return r.Err == nil
+}
+
+func (r ProbeResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s %s: %s", r.Kind, r.Target, r.Err)
+	}
+	return fmt.Sprintf("%s %s [%s]: %s", r.Kind, r.Target, r.Address, r.Rtt.Round(time.Microsecond))
+}
+
+// Target is a parsed probe definition, see ParseTarget
+type Target struct {
+	Kind ProbeKind
+	// Address is a host for icmp and dns probes and host:port for tcp probes
+	Address string
+	// Server is an optional DNS server of dns probes, the system resolver is used if empty
+	Server string
+}
+
+// ParseTarget parses icmp:host, tcp:host:port and dns:host[@server], a plain host means an icmp probe
+func ParseTarget(value string) (Target, error) {
+	kind, address, found := strings.Cut(value, ":")
+	if !found {
+		kind, address = string(ProbeKindIcmp), value
+	}
+
+	target := Target{Kind: ProbeKind(strings.ToLower(kind)), Address: address}
+	switch target.Kind {
+	case ProbeKindIcmp:
+	case ProbeKindTcp:
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return Target{}, errors.Errorf("invalid tcp probe target %s, use tcp:host:port", value)
+		}
+	case ProbeKindDns:
+		target.Address, target.Server, _ = strings.Cut(address, "@")
+	default:
+		return Target{}, errors.Errorf("unknown probe %s, use one of icmp, tcp, dns", kind)
+	}
+	if target.Address == "" {
+		return Target{}, errors.Errorf("probe target %s has no address", value)
+	}
+	return target, nil
+}
+
+// ParseTargets parses a comma separated list of targets
+func ParseTargets(value string) ([]Target, error) {
+	var targets []Target
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		target, err := ParseTarget(item)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func (t Target) String() string {
+	if t.Server != "" {
+		return fmt.Sprintf("%s:%s@%s", t.Kind, t.Address, t.Server)
+	}
+	return fmt.Sprintf("%s:%s", t.Kind, t.Address)
+}
+
+func (t Target) Probe(ctx context.Context) ProbeResult {
+	switch t.Kind {
+	case ProbeKindTcp:
+		return ProbeTcp(ctx, t.Address)
+	case ProbeKindDns:
+		return ProbeDns(ctx, t.Server, t.Address)
+	default:
+		return ProbeIcmp(ctx, t.Address)
+	}
+}
+
+func withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultProbeTimeout)
+}
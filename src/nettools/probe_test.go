@@ -0,0 +1,51 @@
+package nettools
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		value  string
+		target Target
+		err    bool
+	}{
+		{value: "logger.core.zerops", target: Target{Kind: ProbeKindIcmp, Address: "logger.core.zerops"}},
+		{value: "icmp:10.0.0.1", target: Target{Kind: ProbeKindIcmp, Address: "10.0.0.1"}},
+		{value: "TCP:db.zerops:5432", target: Target{Kind: ProbeKindTcp, Address: "db.zerops:5432"}},
+		{value: "dns:app.zerops", target: Target{Kind: ProbeKindDns, Address: "app.zerops"}},
+		{value: "dns:app.zerops@10.0.0.1", target: Target{Kind: ProbeKindDns, Address: "app.zerops", Server: "10.0.0.1"}},
+		{value: "tcp:db.zerops", err: true},
+		{value: "udp:db.zerops", err: true},
+		{value: "dns:", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			target, err := ParseTarget(test.value)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.target, target)
+		})
+	}
+}
+
+func TestProbeTcp(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	result := ProbeTcp(context.Background(), address)
+	require.True(t, result.Ok(), result.String())
+	require.Equal(t, address, result.Address)
+	require.Positive(t, result.Rtt)
+
+	listener.Close()
+	require.False(t, ProbeTcp(context.Background(), address).Ok())
+}
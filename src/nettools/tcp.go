@@ -0,0 +1,28 @@
+package nettools
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// ProbeTcp measures how long it takes to open a TCP connection to the address
+func ProbeTcp(ctx context.Context, address string) ProbeResult {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	result := ProbeResult{Kind: ProbeKindTcp, Target: address}
+
+	var dialer net.Dialer
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Rtt = time.Since(start)
+	result.Address = conn.RemoteAddr().String()
+	conn.Close()
+
+	return result
+}
@@ -0,0 +1,122 @@
+// Package proxyServer exposes a network reachable only through a custom dialer, e.g. a userspace VPN tunnel,
+// via a local SOCKS5/HTTP proxy and plain TCP port forwards.
+package proxyServer
+
+import (
+	"bufio"
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net"
+	"sync"
+)
+
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+type Config struct {
+	Dial DialFunc
+	// OnError is called with errors of single connections, they never stop the server
+	OnError func(err error)
+}
+
+type Handler struct {
+	config Config
+}
+
+func New(config Config) *Handler {
+	if config.OnError == nil {
+		config.OnError = func(error) {}
+	}
+	return &Handler{
+		config: config,
+	}
+}
+
+// Serve accepts SOCKS5 and HTTP proxy connections on the listener until the context is canceled.
+// Both protocols share the port, they are told apart by the first byte of the connection.
+func (h *Handler) Serve(ctx context.Context, listener net.Listener) error {
+	return h.accept(ctx, listener, func(conn net.Conn) error {
+		reader := bufio.NewReader(conn)
+		first, err := reader.Peek(1)
+		if err != nil {
+			return err
+		}
+		bufConn := &bufferedConn{Conn: conn, reader: reader}
+		if first[0] == socks5Version {
+			return h.serveSocks5(ctx, bufConn)
+		}
+		return h.serveHttp(ctx, bufConn)
+	})
+}
+
+// Forward pipes every connection accepted on the listener to the remote address until the context is canceled
+func (h *Handler) Forward(ctx context.Context, listener net.Listener, remoteAddress string) error {
+	return h.accept(ctx, listener, func(conn net.Conn) error {
+		remote, err := h.config.Dial(ctx, "tcp", remoteAddress)
+		if err != nil {
+			return err
+		}
+		pipe(conn, remote)
+		return nil
+	})
+}
+
+func (h *Handler) accept(ctx context.Context, listener net.Listener, handle func(conn net.Conn) error) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+
+			// close the connection when the server stops, so that wg.Wait doesn't hang on open pipes
+			stop := context.AfterFunc(ctx, func() { conn.Close() })
+			defer stop()
+
+			if err := handle(conn); err != nil && ctx.Err() == nil {
+				h.config.OnError(err)
+			}
+		}()
+	}
+}
+
+// pipe copies data in both directions until one of the sides is closed
+func pipe(a, b net.Conn) {
+	defer b.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// bufferedConn keeps bytes already read by the protocol detection
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
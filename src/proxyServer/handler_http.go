@@ -0,0 +1,75 @@
+package proxyServer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// serveHttp handles CONNECT tunnels and plain http requests with an absolute URL
+func (h *Handler) serveHttp(ctx context.Context, conn *bufferedConn) error {
+	req, err := http.ReadRequest(conn.reader)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	address := req.Host
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "80")
+	}
+
+	remote, err := h.config.Dial(ctx, "tcp", address)
+	if err != nil {
+		_ = writeHttpStatus(conn, req, http.StatusBadGateway)
+		return errors.Wrap(err, address)
+	}
+
+	if req.Method == http.MethodConnect {
+		if err := writeHttpStatus(conn, req, http.StatusOK); err != nil {
+			remote.Close()
+			return err
+		}
+		pipe(conn, remote)
+		return nil
+	}
+
+	// a connection can carry requests to different hosts, one request per connection keeps it simple
+	req.Header.Del("Proxy-Connection")
+	req.Header.Del("Proxy-Authorization")
+	req.Header.Set("Connection", "close")
+	if err := req.Write(remote); err != nil {
+		remote.Close()
+		return errors.WithStack(err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(remote), req)
+	if err != nil {
+		remote.Close()
+		return errors.WithStack(err)
+	}
+	defer remote.Close()
+	defer resp.Body.Close()
+
+	resp.Close = true
+	return errors.WithStack(resp.Write(conn))
+}
+
+func writeHttpStatus(conn net.Conn, req *http.Request, status int) error {
+	resp := &http.Response{
+		StatusCode: status,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Request:    req,
+		Header:     http.Header{},
+	}
+	if req.Method == http.MethodConnect && status == http.StatusOK {
+		// the tunnel follows right after the headers, no body length is known
+		_, err := conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		return errors.WithStack(err)
+	}
+	resp.Close = true
+	return errors.WithStack(resp.Write(conn))
+}
@@ -0,0 +1,118 @@
+package proxyServer
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// only the CONNECT command without authentication is supported, see RFC 1928
+const (
+	socks5Version        = 0x05
+	socks5NoAuth         = 0x00
+	socks5NoAcceptable   = 0xff
+	socks5CmdConnect     = 0x01
+	socks5AtypIpv4       = 0x01
+	socks5AtypDomain     = 0x03
+	socks5AtypIpv6       = 0x04
+	socks5Succeeded      = 0x00
+	socks5HostUnreach    = 0x04
+	socks5CmdUnsupported = 0x07
+)
+
+func (h *Handler) serveSocks5(ctx context.Context, conn net.Conn) error {
+	if err := socks5Negotiate(conn); err != nil {
+		return err
+	}
+
+	address, err := socks5ReadRequest(conn)
+	if err != nil {
+		return err
+	}
+
+	remote, err := h.config.Dial(ctx, "tcp", address)
+	if err != nil {
+		_ = socks5Reply(conn, socks5HostUnreach)
+		return errors.Wrap(err, address)
+	}
+	if err := socks5Reply(conn, socks5Succeeded); err != nil {
+		remote.Close()
+		return err
+	}
+
+	pipe(conn, remote)
+	return nil
+}
+
+func socks5Negotiate(conn net.Conn) error {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return errors.WithStack(err)
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, method := range methods {
+		if method == socks5NoAuth {
+			_, err := conn.Write([]byte{socks5Version, socks5NoAuth})
+			return errors.WithStack(err)
+		}
+	}
+	_, _ = conn.Write([]byte{socks5Version, socks5NoAcceptable})
+	return errors.New("socks5: no supported authentication method")
+}
+
+func socks5ReadRequest(conn net.Conn) (string, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if header[1] != socks5CmdConnect {
+		_ = socks5Reply(conn, socks5CmdUnsupported)
+		return "", errors.Errorf("socks5: unsupported command %d", header[1])
+	}
+
+	var host string
+	switch header[3] {
+	case socks5AtypIpv4, socks5AtypIpv6:
+		size := net.IPv4len
+		if header[3] == socks5AtypIpv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", errors.WithStack(err)
+		}
+		host = net.IP(ip).String()
+	case socks5AtypDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return "", errors.WithStack(err)
+		}
+		domain := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", errors.WithStack(err)
+		}
+		host = string(domain)
+	default:
+		return "", errors.Errorf("socks5: unsupported address type %d", header[3])
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// socks5Reply sends a reply with an empty bind address, clients don't need it for CONNECT
+func socks5Reply(conn net.Conn, status byte) error {
+	_, err := conn.Write([]byte{socks5Version, status, 0x00, socks5AtypIpv4, 0, 0, 0, 0, 0, 0})
+	return errors.WithStack(err)
+}
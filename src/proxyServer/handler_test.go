@@ -0,0 +1,123 @@
+package proxyServer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func startProxy(t *testing.T, serve func(h *Handler, ctx context.Context, listener net.Listener) error) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	dialer := &net.Dialer{}
+	h := New(Config{Dial: dialer.DialContext})
+	go func() {
+		done <- serve(h, ctx, listener)
+	}()
+	return listener.Addr().String()
+}
+
+func requireEcho(t *testing.T, conn net.Conn, reader io.Reader) {
+	_, err := conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(reader, buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf))
+}
+
+func TestSocks5Connect(t *testing.T) {
+	echoAddress := startEchoServer(t)
+	proxyAddress := startProxy(t, (*Handler).Serve)
+
+	conn, err := net.Dial("tcp", proxyAddress)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte{socks5Version, 1, socks5NoAuth})
+	require.NoError(t, err)
+	reply := make([]byte, 2)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err)
+	require.Equal(t, []byte{socks5Version, socks5NoAuth}, reply)
+
+	addr, err := net.ResolveTCPAddr("tcp", echoAddress)
+	require.NoError(t, err)
+	request := []byte{socks5Version, socks5CmdConnect, 0x00, socks5AtypIpv4}
+	request = append(request, addr.IP.To4()...)
+	request = append(request, byte(addr.Port>>8), byte(addr.Port))
+	_, err = conn.Write(request)
+	require.NoError(t, err)
+
+	reply = make([]byte, 10)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err)
+	require.Equal(t, byte(socks5Succeeded), reply[1])
+
+	requireEcho(t, conn, conn)
+}
+
+func TestHttpConnect(t *testing.T) {
+	echoAddress := startEchoServer(t)
+	proxyAddress := startProxy(t, (*Handler).Serve)
+
+	conn, err := net.Dial("tcp", proxyAddress)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("CONNECT " + echoAddress + " HTTP/1.1\r\nHost: " + echoAddress + "\r\n\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	requireEcho(t, conn, reader)
+}
+
+func TestForward(t *testing.T) {
+	echoAddress := startEchoServer(t)
+	forwardAddress := startProxy(t, func(h *Handler, ctx context.Context, listener net.Listener) error {
+		return h.Forward(ctx, listener, echoAddress)
+	})
+
+	conn, err := net.Dial("tcp", forwardAddress)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	requireEcho(t, conn, conn)
+}
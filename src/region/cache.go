@@ -0,0 +1,39 @@
+package region
+
+import (
+	"context"
+	"time"
+)
+
+// CacheTtl is how long the cached region list is used without asking the region endpoint
+const CacheTtl = time.Hour
+
+// Cache is the last region list retrieved from Url
+type Cache struct {
+	Url         string
+	Items       []RegionItem
+	RetrievedAt time.Time
+}
+
+// RetrieveAllCached returns the cached list while it is fresh, otherwise the list is retrieved and the new cache returned.
+// A stale cache is used if the region endpoint is unavailable, maxAge zero always tries the endpoint first.
+func (h *Handler) RetrieveAllCached(ctx context.Context, regionURL string, cache Cache, maxAge time.Duration) ([]RegionItem, Cache, error) {
+	hasCache := cache.Url == regionURL && len(cache.Items) > 0
+	if hasCache && time.Since(cache.RetrievedAt) < maxAge {
+		return cache.Items, cache, nil
+	}
+
+	regions, err := h.RetrieveAllFromURL(ctx, regionURL)
+	if err != nil {
+		if hasCache {
+			return cache.Items, cache, nil
+		}
+		return nil, cache, err
+	}
+
+	return regions, Cache{
+		Url:         regionURL,
+		Items:       regions,
+		RetrievedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package region
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zeropsio/zcli/src/httpClient"
+)
+
+func TestRetrieveAllCached(t *testing.T) {
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"dev","address":"dev.example"},{"name":"prg1","isDefault":true,"address":"prg1.example"}]}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	handler := New(httpClient.New(ctx, httpClient.Config{HttpTimeout: time.Second}))
+
+	regions, cache, err := handler.RetrieveAllCached(ctx, server.URL, Cache{}, CacheTtl)
+	require.NoError(t, err)
+	require.Equal(t, "prg1", regions[0].Name)
+	require.Equal(t, server.URL, cache.Url)
+	require.Len(t, cache.Items, 2)
+
+	available = false
+
+	// the stale cache is used when the endpoint fails
+	regions, _, err = handler.RetrieveAllCached(ctx, server.URL, cache, 0)
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+
+	// the cache of another url isn't used
+	_, _, err = handler.RetrieveAllCached(ctx, server.URL+"/other", cache, CacheTtl)
+	require.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package secretStore
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+// scryptWorkFactor is lower than the age default, the file is decrypted by every zcli command
+const scryptWorkFactor = 15
+
+// EncryptedFile keeps secrets in a file encrypted by a passphrase, it's used where no keyring is available
+type EncryptedFile struct {
+	filePath   string
+	passphrase string
+	secrets    map[string]string
+	// modTime of the loaded file, the file is read again when another process changes it
+	modTime time.Time
+}
+
+func NewEncryptedFile(filePath, passphrase string) *EncryptedFile {
+	return &EncryptedFile{
+		filePath:   filePath,
+		passphrase: passphrase,
+	}
+}
+
+func (f *EncryptedFile) Name() string {
+	return NameFile
+}
+
+func (f *EncryptedFile) FilePath() string {
+	return f.filePath
+}
+
+func (f *EncryptedFile) Get(key string) (string, error) {
+	if err := f.load(); err != nil {
+		return "", err
+	}
+	return f.secrets[key], nil
+}
+
+func (f *EncryptedFile) Set(key, value string) error {
+	if err := f.load(); err != nil {
+		return err
+	}
+	if current, exists := f.secrets[key]; exists && current == value {
+		return nil
+	}
+	f.secrets[key] = value
+	return f.save()
+}
+
+func (f *EncryptedFile) Delete(key string) error {
+	if err := f.load(); err != nil {
+		return err
+	}
+	if _, exists := f.secrets[key]; !exists {
+		return nil
+	}
+	delete(f.secrets, key)
+	return f.save()
+}
+
+func (f *EncryptedFile) load() error {
+	fileInfo, err := os.Stat(f.filePath)
+	if os.IsNotExist(err) {
+		f.secrets = make(map[string]string)
+		f.modTime = time.Time{}
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if f.secrets != nil && fileInfo.ModTime().Equal(f.modTime) {
+		return nil
+	}
+
+	content, err := os.ReadFile(f.filePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	identity, err := age.NewScryptIdentity(f.passphrase)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(content), identity)
+	if err != nil {
+		return errors.Wrap(err, i18n.T(i18n.SecretStoreDecryptFailed, f.filePath))
+	}
+	decrypted, err := io.ReadAll(r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(decrypted, &secrets); err != nil {
+		return errors.WithStack(err)
+	}
+	f.secrets, f.modTime = secrets, fileInfo.ModTime()
+	return nil
+}
+
+func (f *EncryptedFile) save() error {
+	recipient, err := age.NewScryptRecipient(f.passphrase)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	recipient.SetWorkFactor(scryptWorkFactor)
+
+	plaintext, err := json.Marshal(f.secrets)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var encrypted bytes.Buffer
+	w, err := age.Encrypt(&encrypted, recipient)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := os.WriteFile(f.filePath+".new", encrypted.Bytes(), 0600); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(f.filePath+".new", f.filePath); err != nil {
+		return errors.WithStack(err)
+	}
+
+	fileInfo, err := os.Stat(f.filePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	f.modTime = fileInfo.ModTime()
+	return nil
+}
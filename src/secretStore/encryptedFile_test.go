@@ -0,0 +1,36 @@
+package secretStore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "cli.data.secrets")
+
+	{
+		store := NewEncryptedFile(filePath, "passphrase")
+		require.NoError(t, store.Set("token", "secret"))
+		require.NoError(t, store.Set("vpnKey/project", "key"))
+		require.NoError(t, store.Delete("vpnKey/project"))
+	}
+
+	{
+		store := NewEncryptedFile(filePath, "passphrase")
+		value, err := store.Get("token")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+
+		value, err = store.Get("vpnKey/project")
+		require.NoError(t, err)
+		require.Equal(t, "", value)
+	}
+
+	{
+		store := NewEncryptedFile(filePath, "wrong")
+		_, err := store.Get("token")
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build linux
+// +build linux
+
+package secretStore
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// kernelKeyPerm grants everything to the possessor and the user of the key, without it a key can be read
+// only by processes whose session keyring links the user keyring
+const kernelKeyPerm = 0x3f3f0000
+
+// KernelKeyring keeps secrets in the user keyring of the linux kernel, e.g. on servers without the Secret Service.
+// The keys are kept only until the machine is restarted, so it is used only if it is chosen explicitly.
+type KernelKeyring struct {
+	// prefix separates secrets of different data files
+	prefix string
+}
+
+func NewKernelKeyring(dataFilePath string) *KernelKeyring {
+	return &KernelKeyring{prefix: keyringService + ":" + dataFilePath + "#"}
+}
+
+func (k *KernelKeyring) Name() string {
+	return NameKernelKeyring
+}
+
+// Available reports whether the keyring can be used, e.g. the default seccomp profile of docker denies keyctl
+func (k *KernelKeyring) Available() bool {
+	_, err := k.search("probe")
+	return err == nil || isKernelKeyMissing(err)
+}
+
+func (k *KernelKeyring) Get(key string) (string, error) {
+	id, err := k.search(key)
+	if isKernelKeyMissing(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	value := make([]byte, size)
+	size, err = unix.KeyctlBuffer(unix.KEYCTL_READ, id, value, 0)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(value[:size]), nil
+}
+
+// Set replaces the payload of an existing key
+func (k *KernelKeyring) Set(key, value string) error {
+	id, err := unix.AddKey("user", k.prefix+key, []byte(value), unix.KEY_SPEC_USER_KEYRING)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(unix.KeyctlSetperm(id, kernelKeyPerm))
+}
+
+func (k *KernelKeyring) Delete(key string) error {
+	id, err := k.search(key)
+	if isKernelKeyMissing(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = unix.KeyctlInt(unix.KEYCTL_UNLINK, id, unix.KEY_SPEC_USER_KEYRING, 0, 0)
+	return errors.WithStack(err)
+}
+
+func (k *KernelKeyring) search(key string) (int, error) {
+	return unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, "user", k.prefix+key, 0)
+}
+
+// isKernelKeyMissing is true for keys which don't exist or can't be used anymore
+func isKernelKeyMissing(err error) bool {
+	return errors.Is(err, unix.ENOKEY) || errors.Is(err, unix.EKEYEXPIRED) || errors.Is(err, unix.EKEYREVOKED)
+}
@@ -0,0 +1,30 @@
+//go:build linux
+// +build linux
+
+package secretStore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKernelKeyring(t *testing.T) {
+	store := NewKernelKeyring(filepath.Join(t.TempDir(), "cli.data"))
+	if !store.Available() {
+		t.Skip("the kernel keyring is not available")
+	}
+
+	require.NoError(t, store.Set("token", "secret"))
+	require.NoError(t, store.Set("token", "newSecret"))
+	value, err := store.Get("token")
+	require.NoError(t, err)
+	require.Equal(t, "newSecret", value)
+
+	require.NoError(t, store.Delete("token"))
+	value, err = store.Get("token")
+	require.NoError(t, err)
+	require.Equal(t, "", value)
+	require.NoError(t, store.Delete("token"))
+}
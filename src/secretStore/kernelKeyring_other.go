@@ -0,0 +1,37 @@
+//go:build !linux
+// +build !linux
+
+package secretStore
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+// KernelKeyring exists only on linux
+type KernelKeyring struct{}
+
+func NewKernelKeyring(string) *KernelKeyring {
+	return &KernelKeyring{}
+}
+
+func (k *KernelKeyring) Name() string {
+	return NameKernelKeyring
+}
+
+func (k *KernelKeyring) Available() bool {
+	return false
+}
+
+func (k *KernelKeyring) Get(string) (string, error) {
+	return "", errors.New(i18n.T(i18n.SecretStoreKernelUnavailable))
+}
+
+func (k *KernelKeyring) Set(string, string) error {
+	return errors.New(i18n.T(i18n.SecretStoreKernelUnavailable))
+}
+
+func (k *KernelKeyring) Delete(string) error {
+	return errors.New(i18n.T(i18n.SecretStoreKernelUnavailable))
+}
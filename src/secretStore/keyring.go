@@ -0,0 +1,49 @@
+package secretStore
+
+import (
+	"github.com/pkg/errors"
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "zcli"
+
+// Keyring keeps secrets in the OS keyring, the Secret Service on linux, Keychain on macOS
+// and the Credential Manager on windows
+type Keyring struct {
+	// prefix separates secrets of different data files
+	prefix string
+}
+
+func NewKeyring(dataFilePath string) *Keyring {
+	return &Keyring{prefix: dataFilePath + "#"}
+}
+
+func (k *Keyring) Name() string {
+	return NameKeyring
+}
+
+// Available reports whether the keyring can be used, e.g. there is no Secret Service in containers
+func (k *Keyring) Available() bool {
+	_, err := keyring.Get(keyringService, k.prefix+"probe")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (k *Keyring) Get(key string) (string, error) {
+	value, err := keyring.Get(keyringService, k.prefix+key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	return value, errors.WithStack(err)
+}
+
+func (k *Keyring) Set(key, value string) error {
+	return errors.WithStack(keyring.Set(keyringService, k.prefix+key, value))
+}
+
+func (k *Keyring) Delete(key string) error {
+	err := keyring.Delete(keyringService, k.prefix+key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return errors.WithStack(err)
+}
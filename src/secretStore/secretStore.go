@@ -0,0 +1,79 @@
+package secretStore
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/constants"
+	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/storage"
+)
+
+const (
+	NameAuto    = "auto"
+	NameKeyring = "keyring"
+	// NameKernelKeyring is the user keyring of the linux kernel
+	NameKernelKeyring = "kernel"
+	NameFile          = "file"
+	// NamePlain keeps secrets in the storage file as older versions did
+	NamePlain = "plain"
+)
+
+// Detect returns the store chosen by ZEROPS_SECRET_STORE, by default the OS keyring is preferred
+// and the encrypted file is used if a passphrase is set. Nil means secrets stay in the data file.
+// The kernel keyring is used only if it is chosen, its secrets are lost on restart.
+// The store the secrets were saved to is kept without probing the keyring again if it is still the chosen one.
+func Detect(dataFilePath, storedName string) (storage.SecretStore, error) {
+	name := strings.ToLower(os.Getenv(constants.CliSecretStoreEnvVar))
+	switch name {
+	case "", NameAuto:
+		if storedName != "" {
+			return Open(storedName, dataFilePath)
+		}
+		if keyring := NewKeyring(dataFilePath); keyring.Available() {
+			return keyring, nil
+		}
+		if os.Getenv(constants.CliSecretPassphraseEnvVar) != "" {
+			return Open(NameFile, dataFilePath)
+		}
+		return nil, nil
+	case NamePlain:
+		return nil, nil
+	}
+
+	if name == storedName {
+		return Open(name, dataFilePath)
+	}
+	// a chosen keyring is checked before anything is written into it
+	switch name {
+	case NameKeyring:
+		if !NewKeyring(dataFilePath).Available() {
+			return nil, errors.New(i18n.T(i18n.SecretStoreKeyringUnavailable))
+		}
+	case NameKernelKeyring:
+		if !NewKernelKeyring(dataFilePath).Available() {
+			return nil, errors.New(i18n.T(i18n.SecretStoreKernelUnavailable))
+		}
+	}
+	return Open(name, dataFilePath)
+}
+
+// Open returns the store of the given name, e.g. the one secrets were saved to by a previous run,
+// an unavailable keyring fails on the first read
+func Open(name, dataFilePath string) (storage.SecretStore, error) {
+	switch name {
+	case NameKeyring:
+		return NewKeyring(dataFilePath), nil
+	case NameKernelKeyring:
+		return NewKernelKeyring(dataFilePath), nil
+	case NameFile:
+		passphrase := os.Getenv(constants.CliSecretPassphraseEnvVar)
+		if passphrase == "" {
+			return nil, errors.New(i18n.T(i18n.SecretStorePassphraseMissing, constants.CliSecretPassphraseEnvVar))
+		}
+		return NewEncryptedFile(dataFilePath+".secrets", passphrase), nil
+	}
+	return nil, errors.New(i18n.T(i18n.SecretStoreUnknown, name))
+}
@@ -7,11 +7,20 @@ const STREAM = "STREAM"
 const APPLICATION = "APPLICATION"
 const WEBSERVER = "WEBSERVER"
 const FULL = "FULL"
+const PRETTY = "PRETTY"
 const SHORT = "SHORT"
 const JSON = "JSON"
 const JSONSTREAM = "JSONSTREAM"
 const AT = "@"
 const HTTPS = "https://"
 const WSS = "wss://"
+const SYSLOG = "syslog://"
+const SYSLOGTCP = "syslog+tcp://"
 const RFC5424 = "5424"
 const RFC3164 = "3164"
+
+// MessageTypes are the allowed values of the --messageType flag
+var MessageTypes = []string{APPLICATION, WEBSERVER}
+
+// Formats are the allowed values of the --format flag, an empty format is chosen by defaultFormat
+var Formats = []string{PRETTY, FULL, SHORT, JSON, JSONSTREAM}
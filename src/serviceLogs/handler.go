@@ -1,6 +1,8 @@
 package serviceLogs
 
 import (
+	"time"
+
 	"github.com/zeropsio/zcli/src/entity"
 	"github.com/zeropsio/zcli/src/zeropsRestApiClient"
 	"github.com/zeropsio/zerops-go/types/uuid"
@@ -22,13 +24,30 @@ type RunConfig struct {
 	FormatTemplate string
 	Follow         bool
 	Levels         Levels
+	Since          time.Duration
+	Output         string
+	RotateSizeMb   int
+	RotateInterval time.Duration
+	IsTerminal     bool
+
+	OnMatch          string
+	AlertExec        string
+	AlertWebhook     string
+	AlertSeverity    string
+	AlertRateLimit   time.Duration
+	AlertDedupWindow time.Duration
 }
 
 type Handler struct {
 	config        Config
 	restApiClient *zeropsRestApiClient.Handler
+	sink          Sink
+	alerts        *alertWatcher
 
-	lastMsgId string
+	lastMsgId        string
+	lastMsgTimestamp string
+	// resuming is set when the stream is reconnected from lastMsgId
+	resuming bool
 }
 
 func New(config Config, restApiClient *zeropsRestApiClient.Handler) *Handler {
@@ -0,0 +1,154 @@
+package serviceLogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+const alertHookTimeout = 30 * time.Second
+
+type alertConfig struct {
+	pattern *regexp.Regexp
+	// maxSeverity is the least important severity which fires a hook, -1 for all
+	maxSeverity int
+	exec        string
+	webhook     string
+	rateLimit   time.Duration
+	dedupWindow time.Duration
+}
+
+// alertWatcher runs hooks for log messages matching the alert config.
+// Equal messages are fired only once per dedupWindow and hooks never run more often than rateLimit.
+type alertWatcher struct {
+	config alertConfig
+	notice func(text string)
+
+	lock      sync.Mutex
+	lastFired time.Time
+	fired     map[string]time.Time
+	wg        sync.WaitGroup
+}
+
+func newAlertWatcher(config alertConfig, notice func(text string)) *alertWatcher {
+	return &alertWatcher{
+		config: config,
+		notice: notice,
+		fired:  make(map[string]time.Time),
+	}
+}
+
+func (a *alertWatcher) Check(ctx context.Context, logData []Data) {
+	now := time.Now()
+	for _, data := range logData {
+		if !a.matches(data) || !a.shouldFire(data, now) {
+			continue
+		}
+
+		a.wg.Add(1)
+		go func(data Data) {
+			defer a.wg.Done()
+			if err := a.fire(ctx, data); err != nil && ctx.Err() == nil {
+				a.notice(i18n.T(i18n.LogAlertHookFailed, err))
+			}
+		}(data)
+	}
+}
+
+// Wait blocks until all running hooks are finished
+func (a *alertWatcher) Wait() {
+	a.wg.Wait()
+}
+
+func (a *alertWatcher) matches(data Data) bool {
+	if a.config.maxSeverity != -1 && data.Severity > a.config.maxSeverity {
+		return false
+	}
+	if a.config.pattern != nil && !a.config.pattern.MatchString(data.Message) {
+		return false
+	}
+	return true
+}
+
+func (a *alertWatcher) shouldFire(data Data, now time.Time) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for key, firedAt := range a.fired {
+		if now.Sub(firedAt) >= a.config.dedupWindow {
+			delete(a.fired, key)
+		}
+	}
+
+	key := data.Hostname + "\x00" + data.Message
+	if _, exists := a.fired[key]; exists {
+		return false
+	}
+	if !a.lastFired.IsZero() && now.Sub(a.lastFired) < a.config.rateLimit {
+		return false
+	}
+
+	a.lastFired = now
+	if a.config.dedupWindow > 0 {
+		a.fired[key] = now
+	}
+	return true
+}
+
+// fire passes the message as JSON to the exec command on stdin or as a webhook request body
+func (a *alertWatcher) fire(ctx context.Context, data Data) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, alertHookTimeout)
+	defer cancel()
+
+	if a.config.exec != "" {
+		cmd := shellCmd(ctx, a.config.exec)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return errors.Wrap(err, a.config.exec)
+		}
+	}
+
+	if a.config.webhook != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.webhook, bytes.NewReader(payload))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		req.Header.Add("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return errors.Errorf("%s: %s", a.config.webhook, resp.Status)
+		}
+	}
+
+	return nil
+}
+
+func shellCmd(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
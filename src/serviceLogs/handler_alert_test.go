@@ -0,0 +1,42 @@
+package serviceLogs
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertWatcherMatches(t *testing.T) {
+	a := newAlertWatcher(alertConfig{
+		pattern:     regexp.MustCompile(`timeout`),
+		maxSeverity: 3,
+	}, func(string) {})
+
+	require.True(t, a.matches(Data{Severity: 3, Message: "db timeout"}))
+	require.True(t, a.matches(Data{Severity: 0, Message: "db timeout"}))
+	require.False(t, a.matches(Data{Severity: 4, Message: "db timeout"}))
+	require.False(t, a.matches(Data{Severity: 3, Message: "db ok"}))
+}
+
+func TestAlertWatcherShouldFire(t *testing.T) {
+	a := newAlertWatcher(alertConfig{
+		maxSeverity: -1,
+		rateLimit:   10 * time.Second,
+		dedupWindow: time.Minute,
+	}, func(string) {})
+
+	now := time.Now()
+	first := Data{Hostname: "app1", Message: "first"}
+	second := Data{Hostname: "app1", Message: "second"}
+
+	require.True(t, a.shouldFire(first, now))
+	// rate limited
+	require.False(t, a.shouldFire(second, now.Add(5*time.Second)))
+	require.True(t, a.shouldFire(second, now.Add(11*time.Second)))
+	// deduplicated
+	require.False(t, a.shouldFire(first, now.Add(30*time.Second)))
+	// dedup window passed
+	require.True(t, a.shouldFire(first, now.Add(61*time.Second)))
+}
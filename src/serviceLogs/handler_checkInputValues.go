@@ -1,23 +1,35 @@
 package serviceLogs
 
 import (
-	"html/template"
+	"regexp"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/zeropsio/zcli/src/i18n"
 )
 
 type InputValues struct {
+	// limit is the page size of the API request, maxItems caps all paged messages with --since
 	limit          int
+	maxItems       int
 	minSeverity    int
 	facility       int
 	format         string
-	formatTemplate string
+	formatTemplate *template.Template
 	mode           string
+	colors         bool
+	since          time.Duration
+	output         string
+	rotateSize     int64
+	rotateInterval time.Duration
+	alert          *alertConfig
 }
 
+const logPageSize = 1000
+
 func (h *Handler) checkInputValues(config RunConfig) (inputValues InputValues, err error) {
 	limit, err := h.getLimit(config)
 	if err != nil {
@@ -27,11 +39,21 @@ func (h *Handler) checkInputValues(config RunConfig) (inputValues InputValues, e
 	if err != nil {
 		return inputValues, err
 	}
-	facility, err := h.getFacility(config)
+	facility := h.getFacility(config)
+	format, formatTemplate, err := h.getFormat(config)
 	if err != nil {
 		return inputValues, err
 	}
-	format, formatTemplate, err := h.getFormat(config)
+
+	since, err := h.getSince(config)
+	if err != nil {
+		return inputValues, err
+	}
+	rotateSize, rotateInterval, err := h.getRotation(config)
+	if err != nil {
+		return inputValues, err
+	}
+	alert, err := h.getAlert(config)
 	if err != nil {
 		return inputValues, err
 	}
@@ -39,24 +61,66 @@ func (h *Handler) checkInputValues(config RunConfig) (inputValues InputValues, e
 	mode := RESPONSE
 	if config.Follow {
 		mode = STREAM
-		if format == JSON {
-			return inputValues, errors.New(i18n.T(i18n.LogFormatStreamMismatch))
-		}
+	}
+	// the time window is paged through, the limit caps the number of all returned messages
+	maxItems := int(limit)
+	if since > 0 && limit > logPageSize {
+		limit = logPageSize
 	}
 	return InputValues{
 		limit:          int(limit),
+		maxItems:       maxItems,
 		minSeverity:    severity,
 		facility:       facility,
 		format:         format,
 		formatTemplate: formatTemplate,
 		mode:           mode,
+		colors:         config.IsTerminal && isStdout(config.Output),
+		since:          since,
+		output:         config.Output,
+		rotateSize:     rotateSize,
+		rotateInterval: rotateInterval,
+		alert:          alert,
 	}, nil
 }
 
+func (h *Handler) getSince(config RunConfig) (time.Duration, error) {
+	if config.Since == 0 {
+		return 0, nil
+	}
+	if config.Follow {
+		return 0, errors.New(i18n.T(i18n.LogSinceFollowMismatch))
+	}
+	if config.Since < 0 {
+		return 0, errors.New(i18n.T(i18n.LogSinceInvalid))
+	}
+	return config.Since, nil
+}
+
+// getRotation returns the max file size in bytes and max file age, rotation makes sense only when following logs into a file
+func (h *Handler) getRotation(config RunConfig) (int64, time.Duration, error) {
+	if config.RotateSizeMb == 0 && config.RotateInterval == 0 {
+		return 0, 0, nil
+	}
+	isFile := !isStdout(config.Output) &&
+		!strings.HasPrefix(config.Output, SYSLOG) && !strings.HasPrefix(config.Output, SYSLOGTCP)
+	if !config.Follow || !isFile {
+		return 0, 0, errors.New(i18n.T(i18n.LogRotateMismatch))
+	}
+	if config.RotateSizeMb < 0 {
+		return 0, 0, errors.New(i18n.T(i18n.LogRotateSizeInvalid))
+	}
+	if config.RotateInterval < 0 {
+		return 0, 0, errors.New(i18n.T(i18n.LogRotateIntervalInvalid))
+	}
+	return int64(config.RotateSizeMb) << 20, config.RotateInterval, nil
+}
+
+// getLimit allows more than one page of messages only with --since, the log is paged through then
 func (h *Handler) getLimit(config RunConfig) (limit uint32, err error) {
 	limit = config.Limit
 
-	if limit < 1 || limit > 1000 {
+	if limit < 1 || (limit > logPageSize && config.Since <= 0) {
 		err = errors.New(i18n.T(i18n.LogLimitInvalid))
 		return limit, err
 	}
@@ -65,64 +129,107 @@ func (h *Handler) getLimit(config RunConfig) (limit uint32, err error) {
 }
 
 func (h *Handler) getMinSeverity(config RunConfig) (intVal int, err error) {
-	ms := config.MinSeverity
+	return parseSeverity(config.Levels, config.MinSeverity, i18n.T(i18n.LogMinSeverityInvalid))
+}
+
+// parseSeverity returns -1 if the severity is not required by user, it is used to make query
+func parseSeverity(levels Levels, ms string, invalidText string) (int, error) {
 	if ms == "" {
-		// -1 for min.severity not required by user, used to make query
 		return -1, nil
 	}
 
-	for key, val := range config.Levels {
+	for key, val := range levels {
 		if strings.ToUpper(ms) == val[0] || ms == val[1] {
 			return key, nil
 		}
 	}
-	_, err = strconv.Atoi(ms)
+	_, err := strconv.Atoi(ms)
 	if err != nil {
-		return 1, errors.Errorf("%s %s", i18n.T(i18n.LogMinSeverityInvalid), i18n.T(i18n.LogMinSeverityStringLimitErr))
+		return 1, errors.Errorf("%s %s", invalidText, i18n.T(i18n.LogMinSeverityStringLimitErr))
 	}
-	return 1, errors.Errorf("%s %s", i18n.T(i18n.LogMinSeverityInvalid), i18n.T(i18n.LogMinSeverityNumLimitErr))
+	return 1, errors.Errorf("%s %s", invalidText, i18n.T(i18n.LogMinSeverityNumLimitErr))
 }
 
-// getFacility returns facility number based on msgType
-func (h *Handler) getFacility(config RunConfig) (int, error) {
-	mt := strings.ToUpper(config.MsgType)
-	if mt == APPLICATION {
-		return 16, nil
+func (h *Handler) getAlert(config RunConfig) (*alertConfig, error) {
+	if config.AlertExec == "" && config.AlertWebhook == "" {
+		if config.OnMatch != "" || config.AlertSeverity != "" {
+			return nil, errors.New(i18n.T(i18n.LogAlertHookMissing))
+		}
+		return nil, nil
+	}
+
+	alert := &alertConfig{
+		exec:    config.AlertExec,
+		webhook: config.AlertWebhook,
+	}
+
+	var err error
+	if config.OnMatch != "" {
+		alert.pattern, err = regexp.Compile(config.OnMatch)
+		if err != nil {
+			return nil, errors.Errorf("%s %s", i18n.T(i18n.LogAlertPatternInvalid), err)
+		}
+	}
+
+	alert.maxSeverity, err = parseSeverity(config.Levels, config.AlertSeverity, i18n.T(i18n.LogAlertSeverityInvalid))
+	if err != nil {
+		return nil, err
 	}
-	if mt == WEBSERVER {
-		return 17, nil
+
+	if config.AlertRateLimit < 0 {
+		return nil, errors.New(i18n.T(i18n.LogAlertRateLimitInvalid))
 	}
-	return 16, errors.New(i18n.T(i18n.LogMsgTypeInvalid))
+	if config.AlertDedupWindow < 0 {
+		return nil, errors.New(i18n.T(i18n.LogAlertDedupWindowInvalid))
+	}
+	alert.rateLimit, alert.dedupWindow = config.AlertRateLimit, config.AlertDedupWindow
+
+	return alert, nil
+}
+
+// getFacility returns facility number based on msgType, the value is validated by the --messageType enum flag
+func (h *Handler) getFacility(config RunConfig) int {
+	if strings.ToUpper(config.MsgType) == WEBSERVER {
+		return 17
+	}
+	return 16
 }
 
-func (h *Handler) getFormat(config RunConfig) (string, string, error) {
+func (h *Handler) getFormat(config RunConfig) (string, *template.Template, error) {
 	f, ft := strings.ToUpper(config.Format), config.FormatTemplate
-	formatValid := f == FULL || f == SHORT || f == JSON || f == JSONSTREAM
-	if !formatValid {
-		return "", "", errors.New(i18n.T(i18n.LogFormatInvalid))
+	if f == "" {
+		f = defaultFormat(config)
 	}
 	if ft == "" {
-		return f, ft, nil
+		return f, nil, nil
 	}
 	if f != FULL {
-		return "", "", errors.New(i18n.T(i18n.LogFormatTemplateMismatch))
+		return "", nil, errors.New(i18n.T(i18n.LogFormatTemplateMismatch))
 	}
 	formatTemplate, err := h.checkFormat(ft)
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 	return f, formatTemplate, nil
 }
 
-// e.g. --formatTemplate="{{.timestamp}} {{.priority}} {{.facility}} {{.message}}"
-func (h *Handler) checkFormat(ft string) (string, error) {
-	if err := validateTemplate(ft); err != nil {
-		return "", errors.Errorf("%s %s", i18n.T(i18n.LogFormatTemplateInvalid), err)
+// defaultFormat is human-friendly in a terminal, the complete syslog format is kept for pipes and files
+func defaultFormat(config RunConfig) string {
+	if config.IsTerminal && isStdout(config.Output) && config.FormatTemplate == "" {
+		return PRETTY
 	}
-	return ft, nil
+	return FULL
 }
 
-func validateTemplate(s string) error {
-	_, err := template.New("").Parse(s)
-	return err
+func isStdout(output string) bool {
+	return output == "" || output == "-"
+}
+
+// e.g. --formatTemplate="{{.timestamp}} {{.priority}} {{.facility}} {{.message}}"
+func (h *Handler) checkFormat(ft string) (*template.Template, error) {
+	t, err := parseTemplate(ft)
+	if err != nil {
+		return nil, errors.Errorf("%s %s", i18n.T(i18n.LogFormatTemplateInvalid), err)
+	}
+	return t, nil
 }
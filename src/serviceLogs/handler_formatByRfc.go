@@ -15,24 +15,28 @@ package serviceLogs
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 )
 
-func getFullByRfc(logData []Data, rfc string) {
+func getFullByRfc(w io.Writer, logData []Data, rfc string) error {
 	if rfc == RFC3164 {
 		for _, data := range logData {
-			fmt.Printf("<%d>%s %s %s: %s\n",
+			_, err := fmt.Fprintf(w, "<%d>%s %s %s: %s\n",
 				data.Priority,
 				rfc3164TimeFormat(fixTimestamp(data.Timestamp)),
 				data.Hostname,
 				data.Tag,
 				data.Message,
 			)
+			if err != nil {
+				return err
+			}
 		}
 	} else {
 		for _, data := range logData {
-			fmt.Printf("<%d>1 %v %s %s %s %s - %s\n",
+			_, err := fmt.Fprintf(w, "<%d>1 %v %s %s %s %s - %s\n",
 				data.Priority,
 				fixTimestamp(data.Timestamp),
 				data.Hostname,
@@ -41,8 +45,12 @@ func getFullByRfc(logData []Data, rfc string) {
 				getVal(data.MsgId),
 				data.Message,
 			)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
 // add missing 0 to have the same length for all timestamps
@@ -2,24 +2,26 @@ package serviceLogs
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"text/template"
+	"text/template/parse"
+	"time"
 
 	"github.com/pkg/errors"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 
 	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
 )
 
-func getFullWithTemplate(logData []Data, formatTemplate string) error {
-	ft, err := fixTemplate(formatTemplate)
-	if err != nil {
-		return err
-	}
+const timestampLayout = "2006-01-02T15:04:05.000000Z"
+
+func getFullWithTemplate(w io.Writer, logData []Data, formatTemplate *template.Template) error {
 	for _, o := range logData {
-		err := formatDataByTemplate(o, ft)
+		err := formatDataByTemplate(w, o, formatTemplate)
 		if err != nil {
 			return errors.Errorf("%s %s", i18n.T(i18n.LogFormatTemplateInvalid), err)
 		}
@@ -27,47 +29,149 @@ func getFullWithTemplate(logData []Data, formatTemplate string) error {
 	return nil
 }
 
-func formatDataByTemplate(data Data, formatTemplate string) error {
+func formatDataByTemplate(w io.Writer, data Data, formatTemplate *template.Template) error {
 	var b bytes.Buffer
-	t, err := template.New("").Parse(formatTemplate)
+	err := formatTemplate.Execute(&b, data)
 	if err != nil {
 		return err
 	}
-	err = t.Execute(&b, data)
+	b.WriteByte('\n')
+	_, err = w.Write(b.Bytes())
+	return err
+}
+
+// parseTemplate compiles the user template with the helper functions.
+// Field names are matched case-insensitively, so both {{.message}} and {{.Message}} work.
+// e.g. --formatTemplate='{{time "15:04" .timestamp}} {{pad 13 .severityLabel | severityColor .severity}} {{.message}}'
+func parseTemplate(formatTemplate string) (*template.Template, error) {
+	t, err := template.New("").Funcs(templateFuncs()).Parse(formatTemplate)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	fmt.Println(b.String())
-	return nil
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			fixFieldNames(tmpl.Tree.Root)
+		}
+	}
+	return t, nil
 }
 
-// test if there are any merged template items and return error
-func testTokens(tokens []string) error {
-	for _, token := range tokens {
-		// if any `{` characters are left, it means the items were not split by correctly
-		rmLeft := strings.Replace(token, "{", "", 2)
-		if strings.Contains(rmLeft, "{") {
-			return errors.Errorf("%s %s", i18n.T(i18n.LogFormatTemplateInvalid), i18n.T(i18n.LogFormatTemplateNoSpace))
-		}
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"color":         colorFunc,
+		"pad":           padFunc,
+		"time":          timeFunc,
+		"json":          jsonFunc,
+		"severityColor": severityColorFunc,
 	}
-	return nil
 }
 
-// change lowercase first letters to uppercase to match the struct
-func fixTemplate(template string) (string, error) {
-	tokens := strings.Split(template, "} {")
-	if err := testTokens(tokens); err != nil {
+// colorFunc renders the text in a named color, a hex code or an ANSI color number
+func colorFunc(color string, value any) string {
+	if code, exists := namedColors[strings.ToLower(color)]; exists {
+		color = code
+	}
+	return styles.ForegroundColor(color).Render(fmt.Sprint(value))
+}
+
+var namedColors = map[string]string{
+	"black":   "0",
+	"red":     "1",
+	"green":   "2",
+	"yellow":  "3",
+	"blue":    "4",
+	"magenta": "5",
+	"cyan":    "6",
+	"white":   "7",
+	"gray":    "8",
+	"grey":    "8",
+}
+
+// padFunc pads the value with spaces to the given width, a negative width aligns it to the right
+func padFunc(width int, value any) string {
+	return fmt.Sprintf("%*s", -width, fmt.Sprint(value))
+}
+
+// timeFunc reformats a log timestamp using the Go time layout
+func timeFunc(layout string, timestamp string) (string, error) {
+	t, err := time.Parse(timestampLayout, fixTimestamp(timestamp))
+	if err != nil {
 		return "", err
 	}
-	repl := strings.NewReplacer("{", "", "}", "", ".", "")
-	keys := make([]string, len(tokens))
-
-	for i, val := range tokens {
-		out := strings.Trim(repl.Replace(val), " ")
-		titleStr := cases.Title(language.Und, cases.NoLower).String(out)
-		item := fmt.Sprintf("{{.%s}}", titleStr)
-		keys[i] = item
+	return t.Local().Format(layout), nil
+}
+
+func jsonFunc(value any) (string, error) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func severityColorFunc(severity int, value any) string {
+	return styles.SeverityColor(severity).Render(fmt.Sprint(value))
+}
+
+var dataFieldNames = func() map[string]string {
+	names := map[string]string{}
+	dataType := reflect.TypeOf(Data{})
+	for i := 0; i < dataType.NumField(); i++ {
+		names[strings.ToLower(dataType.Field(i).Name)] = dataType.Field(i).Name
 	}
+	return names
+}()
+
+// fixFieldNames changes the first field of each `.field` and `$.field` chain to match the Data struct,
+// so templates written with lowercase json names keep working
+func fixFieldNames(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			fixFieldNames(child)
+		}
+	case *parse.ActionNode:
+		fixFieldNames(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			fixFieldNames(cmd)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			fixFieldNames(arg)
+		}
+	case *parse.ChainNode:
+		fixFieldNames(n.Node)
+	case *parse.IfNode:
+		fixBranchFieldNames(&n.BranchNode)
+	case *parse.RangeNode:
+		fixBranchFieldNames(&n.BranchNode)
+	case *parse.WithNode:
+		fixBranchFieldNames(&n.BranchNode)
+	case *parse.TemplateNode:
+		fixFieldNames(n.Pipe)
+	case *parse.FieldNode:
+		if name, exists := dataFieldNames[strings.ToLower(n.Ident[0])]; exists {
+			n.Ident[0] = name
+		}
+	case *parse.VariableNode:
+		// $ is the log message even inside range and with, e.g. {{$.message}}
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			if name, exists := dataFieldNames[strings.ToLower(n.Ident[1])]; exists {
+				n.Ident[1] = name
+			}
+		}
+	}
+}
 
-	return strings.Join(keys, " "), nil
+func fixBranchFieldNames(n *parse.BranchNode) {
+	fixFieldNames(n.Pipe)
+	fixFieldNames(n.List)
+	fixFieldNames(n.ElseList)
 }
@@ -1,22 +1,73 @@
 package serviceLogs
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
-func TestTemplateFixOk(t *testing.T) {
-	got, _ := fixTemplate("{{.timestamp}} {{.severityLabel}} {{.severity  }} {{.facility}} {{ .message}}")
-	want := "{{.Timestamp}} {{.SeverityLabel}} {{.Severity}} {{.Facility}} {{.Message}}"
+func TestParseTemplate(t *testing.T) {
+	data := Data{
+		Timestamp:     "2024-03-01T10:20:30.123456Z",
+		Severity:      3,
+		SeverityLabel: "ERROR",
+		Facility:      16,
+		Message:       "boom",
+	}
 
-	if got != want {
-		t.Errorf("got %q, wanted %q", got, want)
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "lowercase fields",
+			template: "{{.timestamp}} {{.severityLabel}} {{.severity  }} {{.facility}} {{ .message}}",
+			want:     "2024-03-01T10:20:30.123456Z ERROR 3 16 boom",
+		},
+		{
+			name:     "root variable inside with",
+			template: "{{with .severityLabel}}{{.}} {{$.message}}{{end}} {{$.Facility}}",
+			want:     "ERROR boom 16",
+		},
+		{
+			name:     "struct fields, no spaces",
+			template: "{{.SeverityLabel}}:{{.Message}}",
+			want:     "ERROR:boom",
+		},
+		{
+			name:     "conditions",
+			template: "{{if le .severity 3}}!{{end}}{{with .message}}{{.}}{{end}}",
+			want:     "!boom",
+		},
+		{
+			name:     "pad",
+			template: "[{{pad 7 .severityLabel}}][{{pad -6 .message}}]",
+			want:     "[ERROR  ][  boom]",
+		},
+		{
+			name:     "json",
+			template: `{{json .message}}`,
+			want:     `"boom"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseTemplate(tt.template)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, tmpl.Execute(&b, data))
+			require.Equal(t, tt.want, b.String())
+		})
 	}
 }
 
-func TestTemplateFixNotOk(t *testing.T) {
-	_, err := fixTemplate("{{.timestamp}}  {{.severityLabel}} {{.severity  }} {{.facility}} {{ .message}}")
+func TestParseTemplateInvalid(t *testing.T) {
+	_, err := parseTemplate("{{.message")
+	require.Error(t, err)
 
-	if err == nil {
-		t.Errorf("got %v, wanted %q", nil, err)
-	}
+	_, err = parseTemplate("{{unknown .message}}")
+	require.Error(t, err)
 }
@@ -3,31 +3,42 @@ package serviceLogs
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 )
 
-func parseResponseByFormat(jsonData Response, format, formatTemplate, mode string) error {
+func parseResponseByFormat(w io.Writer, jsonData Response, inputs InputValues) error {
 	var err error
 
+	format, formatTemplate, mode := inputs.format, inputs.formatTemplate, inputs.mode
+
 	logs := jsonData.Items
 	if mode == RESPONSE {
 		logs = reverseLogs(logs)
 	}
 
+	// a stream can't be wrapped into a single JSON array, each message is printed on its own line (NDJSON)
+	if format == JSON && mode == STREAM {
+		format = JSONSTREAM
+	}
+
 	switch format {
+	case PRETTY:
+		return getPretty(w, logs, inputs.colors)
 	case FULL:
-		if formatTemplate != "" {
-			if err = getFullWithTemplate(logs, formatTemplate); err != nil {
+		if formatTemplate != nil {
+			if err = getFullWithTemplate(w, logs, formatTemplate); err != nil {
 				return err
 			}
 			return nil
 		} else {
 			// TODO get rfc from config when implemented as flag
-			getFullByRfc(logs, RFC5424)
-			return nil
+			return getFullByRfc(w, logs, RFC5424)
 		}
 	case SHORT:
 		for _, o := range logs {
-			fmt.Printf("%v %s \n", o.Timestamp, o.Content)
+			if _, err := fmt.Fprintf(w, "%v %s \n", o.Timestamp, o.Content); err != nil {
+				return err
+			}
 		}
 	case JSONSTREAM:
 		for _, o := range logs {
@@ -35,14 +46,18 @@ func parseResponseByFormat(jsonData Response, format, formatTemplate, mode strin
 			if err != nil {
 				return err
 			}
-			fmt.Println(string(val))
+			if _, err := fmt.Fprintln(w, string(val)); err != nil {
+				return err
+			}
 		}
 	default:
 		val, err := json.Marshal(logs)
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(val))
+		if _, err := fmt.Fprintln(w, string(val)); err != nil {
+			return err
+		}
 	}
 
 	return nil
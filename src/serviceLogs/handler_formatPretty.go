@@ -0,0 +1,69 @@
+package serviceLogs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
+)
+
+const prettyTimeFormat = "Jan 02 15:04:05"
+
+var shortSeverityLabels = [8]string{"EMERG", "ALERT", "CRIT", "ERROR", "WARN", "NOTICE", "INFO", "DEBUG"}
+
+// getPretty prints logs in a human-friendly form: local time, severity, hostname and the message
+func getPretty(w io.Writer, logData []Data, colors bool) error {
+	for _, data := range logData {
+		if _, err := io.WriteString(w, formatPretty(data, colors)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatPretty(data Data, colors bool) string {
+	render := func(style lipgloss.Style, text string) string {
+		if !colors {
+			return text
+		}
+		return style.Render(text)
+	}
+
+	timestamp := data.Timestamp
+	if t, err := time.Parse(time.RFC3339Nano, data.Timestamp); err == nil {
+		timestamp = t.Local().Format(prettyTimeFormat)
+	}
+
+	severity := data.SeverityLabel
+	if data.Severity >= 0 && data.Severity < len(shortSeverityLabels) {
+		severity = shortSeverityLabels[data.Severity]
+	}
+
+	prefix := fmt.Sprintf("%s %-6s %s ", timestamp, severity, data.Hostname)
+	styledPrefix := render(styles.InfoColor().Faint(true), timestamp) + " " +
+		render(styles.SeverityColor(data.Severity).Bold(true), fmt.Sprintf("%-6s", severity)) + " " +
+		render(styles.SelectColor(), data.Hostname) + " "
+
+	message := data.Message
+	if message == "" {
+		message = data.Content
+	}
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+
+	// continuation lines, e.g. stack traces, are aligned under the first line of the message
+	indent := strings.Repeat(" ", len(prefix))
+	var b strings.Builder
+	b.WriteString(styledPrefix)
+	b.WriteString(lines[0])
+	b.WriteByte('\n')
+	for _, line := range lines[1:] {
+		b.WriteString(indent)
+		b.WriteString(render(styles.InfoColor().Faint(true), line))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
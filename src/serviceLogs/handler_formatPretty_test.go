@@ -0,0 +1,25 @@
+package serviceLogs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPretty(t *testing.T) {
+	timestamp := time.Date(2024, 3, 1, 10, 20, 30, 0, time.UTC)
+	data := Data{
+		Timestamp:     timestamp.Format(time.RFC3339Nano),
+		Hostname:      "app1",
+		Severity:      3,
+		SeverityLabel: "ERROR",
+		Message:       "panic: boom\ngoroutine 1 [running]:\n",
+	}
+
+	prefix := timestamp.Local().Format(prettyTimeFormat) + " ERROR  app1 "
+	want := prefix + "panic: boom\n" +
+		strings.Repeat(" ", len(prefix)) + "goroutine 1 [running]:\n"
+	require.Equal(t, want, formatPretty(data, false))
+}
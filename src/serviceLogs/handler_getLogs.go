@@ -3,9 +3,12 @@ package serviceLogs
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/zeropsio/zcli/src/i18n"
 )
 
 type Response struct {
@@ -33,19 +36,94 @@ type Data struct {
 	Message        string `json:"message"`
 }
 
-func getLogs(ctx context.Context, method, url, format, formatTemplate, mode string) error {
+func (h *Handler) getLogs(ctx context.Context, method, url string, inputs InputValues) error {
+	jsonData, err := fetchLogs(ctx, method, url)
+	if err != nil {
+		return err
+	}
+	if inputs.since > 0 {
+		var incompleteFrom string
+		jsonData.Items, incompleteFrom, err = fetchLogsSince(jsonData.Items, inputs, func(from string) ([]Data, error) {
+			jsonData, err := fetchLogs(ctx, method, url+fmt.Sprintf("&from=%s", from))
+			return jsonData.Items, err
+		})
+		if err != nil {
+			return err
+		}
+		if incompleteFrom != "" {
+			h.printNotice(i18n.T(i18n.LogSinceIncomplete, incompleteFrom))
+		}
+	}
+	if h.alerts != nil {
+		h.alerts.Check(ctx, jsonData.Items)
+	}
+	return parseResponseByFormat(h.sink, jsonData, inputs)
+}
+
+// fetchLogsSince pages through older logs until the beginning of the time window or maxItems messages are reached.
+// Items are in DESC order, `from` starts the next page with the oldest message of the previous one in the order
+// of `desc`, the same way the stream is resumed, see dropReplayed. Already seen messages are skipped.
+// If a full page brings no older messages, the paging stops and the timestamp of the oldest message is returned,
+// the output doesn't cover the whole window then.
+func fetchLogsSince(page []Data, inputs InputValues, fetchPage func(from string) ([]Data, error)) ([]Data, string, error) {
+	sinceTime := time.Now().Add(-inputs.since)
+
+	var logs []Data
+	seen := make(map[string]struct{})
+	for {
+		var added int
+		for _, item := range page {
+			if _, exists := seen[item.Id]; exists {
+				continue
+			}
+			if isOlderThan(item, sinceTime) {
+				return logs, "", nil
+			}
+			seen[item.Id] = struct{}{}
+			logs = append(logs, item)
+			added++
+			if len(logs) >= inputs.maxItems {
+				return logs, "", nil
+			}
+		}
+
+		// the beginning of the log is reached
+		if len(page) < inputs.limit {
+			return logs, "", nil
+		}
+		if added == 0 {
+			return logs, logs[len(logs)-1].Timestamp, nil
+		}
+
+		var err error
+		page, err = fetchPage(page[len(page)-1].Id)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+}
+
+func isOlderThan(item Data, t time.Time) bool {
+	timestamp, err := time.Parse(time.RFC3339Nano, item.Timestamp)
+	if err != nil {
+		return false
+	}
+	return timestamp.Before(t)
+}
+
+func fetchLogs(ctx context.Context, method, url string) (Response, error) {
 	c := http.Client{Timeout: time.Duration(1) * time.Minute}
 
 	req, err := http.NewRequest(method, url, nil)
 	if err != nil {
-		return err
+		return Response{}, err
 	}
 	req = req.WithContext(ctx)
 	req.Header.Add("Content-Type", "application/json")
 
 	resp, err := c.Do(req)
 	if err != nil {
-		return err
+		return Response{}, err
 	}
 
 	defer resp.Body.Close()
@@ -53,18 +131,10 @@ func getLogs(ctx context.Context, method, url, format, formatTemplate, mode stri
 	body, err := io.ReadAll(resp.Body)
 
 	if err != nil {
-		return err
+		return Response{}, err
 	}
 
-	jsonData, err := parseResponse(body)
-	if err != nil {
-		return err
-	}
-	err = parseResponseByFormat(jsonData, format, formatTemplate, mode)
-	if err != nil {
-		return err
-	}
-	return nil
+	return parseResponse(body)
 }
 
 func parseResponse(body []byte) (Response, error) {
@@ -0,0 +1,78 @@
+package serviceLogs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchLogsSince(t *testing.T) {
+	now := time.Now()
+	// messages 1..9 are one minute apart in DESC order, message 1 is the newest
+	message := func(i int) Data {
+		return Data{
+			Id:        strconv.Itoa(i),
+			Timestamp: now.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339Nano),
+		}
+	}
+	messages := func(from, to int) []Data {
+		var items []Data
+		for i := from; i <= to; i++ {
+			items = append(items, message(i))
+		}
+		return items
+	}
+	ids := func(items []Data) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Id)
+		}
+		return result
+	}
+
+	// the next page starts with the boundary message and the one before it again
+	fetchOverlapping := func(from string) ([]Data, error) {
+		i, err := strconv.Atoi(from)
+		require.NoError(t, err)
+		return messages(i-1, min(i+2, 9)), nil
+	}
+
+	items, _, err := fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 100, since: time.Hour}, fetchOverlapping)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, ids(items))
+
+	// the API doesn't return the boundary message
+	fetchExclusive := func(from string) ([]Data, error) {
+		i, err := strconv.Atoi(from)
+		require.NoError(t, err)
+		return messages(i+1, min(i+3, 9)), nil
+	}
+	items, _, err = fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 100, since: time.Hour}, fetchExclusive)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, ids(items))
+
+	// the time window ends in the middle of a page
+	items, _, err = fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 100, since: 4*time.Minute + 30*time.Second}, fetchOverlapping)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4"}, ids(items))
+
+	// --limit caps all messages of the window
+	items, _, err = fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 5, since: time.Hour}, fetchOverlapping)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, ids(items))
+
+	// the API ignores `from`, a page with already seen messages stops the paging and the output is reported incomplete
+	items, incompleteFrom, err := fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 100, since: time.Hour}, func(string) ([]Data, error) {
+		return messages(1, 3), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, ids(items))
+	require.Equal(t, message(3).Timestamp, incompleteFrom)
+
+	// the whole window is returned
+	_, incompleteFrom, err = fetchLogsSince(messages(1, 3), InputValues{limit: 3, maxItems: 100, since: time.Hour}, fetchOverlapping)
+	require.NoError(t, err)
+	require.Empty(t, incompleteFrom)
+}
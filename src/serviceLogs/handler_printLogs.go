@@ -23,14 +23,13 @@ func (h *Handler) printLogs(
 	query := makeQueryParams(inputs, serviceId, containerId)
 
 	if inputs.mode == RESPONSE {
-		err = getLogs(ctx, method, HTTPS+url+query, inputs.format, inputs.formatTemplate, inputs.mode)
+		err = h.getLogs(ctx, method, HTTPS+url+query, inputs)
 		if err != nil {
 			return err
 		}
 	}
 	if inputs.mode == STREAM {
-		wsUrl := getWsUrl(url)
-		err := h.getLogStream(ctx, inputs, projectId, serviceId, containerId, wsUrl, query)
+		err := h.getLogStream(ctx, inputs, projectId, getWsUrl(url), query)
 		if err != nil {
 			return err
 		}
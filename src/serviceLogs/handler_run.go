@@ -10,6 +10,17 @@ func (h *Handler) Run(ctx context.Context, config RunConfig) error {
 		return err
 	}
 
+	h.sink, err = newSink(inputs)
+	if err != nil {
+		return err
+	}
+	defer h.sink.Close()
+
+	if inputs.alert != nil {
+		h.alerts = newAlertWatcher(*inputs.alert, h.printNotice)
+		defer h.alerts.Wait()
+	}
+
 	// TODO - janhajek check empty containerID
 	if err = h.printLogs(ctx, inputs, config.Project.ID, config.ServiceId, config.Container.ID); err != nil {
 		return err
@@ -0,0 +1,224 @@
+package serviceLogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/file"
+)
+
+const rotatedFileTimeFormat = "20060102T150405"
+
+// Sink is a destination of formatted log messages.
+// Each Write call carries one or more whole lines, Flush is called after every batch of messages.
+type Sink interface {
+	io.Writer
+	Flush() error
+	Close() error
+}
+
+func newSink(inputs InputValues) (Sink, error) {
+	output := inputs.output
+	switch {
+	case isStdout(output):
+		return stdoutSink{}, nil
+	case strings.HasPrefix(output, SYSLOG):
+		return newSyslogSink("udp", strings.TrimPrefix(output, SYSLOG))
+	case strings.HasPrefix(output, SYSLOGTCP):
+		return newSyslogSink("tcp", strings.TrimPrefix(output, SYSLOGTCP))
+	default:
+		return newFileSink(output, inputs.rotateSize, inputs.rotateInterval)
+	}
+}
+
+type stdoutSink struct{}
+
+func (stdoutSink) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+func (stdoutSink) Flush() error {
+	return nil
+}
+
+func (stdoutSink) Close() error {
+	return nil
+}
+
+// syslogSink forwards every line as a single message to a syslog server
+type syslogSink struct {
+	conn    net.Conn
+	network string
+}
+
+func newSyslogSink(network, address string) (*syslogSink, error) {
+	conn, err := net.DialTimeout(network, address, 10*time.Second)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &syslogSink{
+		conn:    conn,
+		network: network,
+	}, nil
+}
+
+func (s *syslogSink) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(bytes.TrimRight(p, "\n"), []byte("\n")) {
+		// udp messages are framed by datagrams, tcp ones by a trailing new line
+		if s.network == "tcp" {
+			line = append(line, '\n')
+		}
+		if _, err := s.conn.Write(line); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+	return len(p), nil
+}
+
+func (s *syslogSink) Flush() error {
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.conn.Close()
+}
+
+// fileSink appends logs to a file, gzip compressed if the file name ends with .gz.
+// The file is rotated once it reaches the (uncompressed) rotateSize or is older than rotateInterval.
+type fileSink struct {
+	path           string
+	compress       bool
+	rotateSize     int64
+	rotateInterval time.Duration
+
+	f        *os.File
+	gz       *gzip.Writer
+	written  int64
+	openedAt time.Time
+}
+
+func newFileSink(path string, rotateSize int64, rotateInterval time.Duration) (*fileSink, error) {
+	s := &fileSink{
+		path:           path,
+		compress:       strings.HasSuffix(path, ".gz"),
+		rotateSize:     rotateSize,
+		rotateInterval: rotateInterval,
+	}
+	return s, s.open()
+}
+
+func (s *fileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := file.Open(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	s.f = f
+	if s.compress {
+		s.gz = gzip.NewWriter(f)
+	}
+	s.written = 0
+	s.openedAt = time.Now()
+	return nil
+}
+
+func (s *fileSink) Write(p []byte) (int, error) {
+	if s.shouldRotate() {
+		if err := s.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	var w io.Writer = s.f
+	if s.gz != nil {
+		w = s.gz
+	}
+	n, err := w.Write(p)
+	s.written += int64(n)
+	if err != nil {
+		return n, errors.WithStack(err)
+	}
+	return n, nil
+}
+
+func (s *fileSink) shouldRotate() bool {
+	if s.rotateSize > 0 && s.written >= s.rotateSize {
+		return true
+	}
+	if s.rotateInterval > 0 && time.Since(s.openedAt) >= s.rotateInterval {
+		return true
+	}
+	return false
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+	rotatedPath, err := freeRotatedFilePath(s.path, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return errors.WithStack(err)
+	}
+	return s.open()
+}
+
+// Flush completes the compressed block, so that the file can be read while it is still written, e.g. with --follow
+func (s *fileSink) Flush() error {
+	if s.gz == nil {
+		return nil
+	}
+	return errors.WithStack(s.gz.Flush())
+}
+
+func (s *fileSink) Close() error {
+	if s.gz != nil {
+		if err := s.gz.Close(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return s.f.Close()
+}
+
+// rotatedFilePath inserts a timestamp before the file extensions, e.g. logs/api.ndjson.gz => logs/api.20240301T102030.ndjson.gz,
+// a non-zero sequence separates files rotated within the same second, e.g. logs/api.20240301T102030-1.ndjson.gz
+func rotatedFilePath(path string, t time.Time, sequence int) string {
+	dir, base := filepath.Split(path)
+	name, ext, _ := strings.Cut(base, ".")
+	if ext != "" {
+		ext = "." + ext
+	}
+	suffix := t.Format(rotatedFileTimeFormat)
+	if sequence > 0 {
+		suffix += "-" + strconv.Itoa(sequence)
+	}
+	return filepath.Join(dir, name+"."+suffix+ext)
+}
+
+// freeRotatedFilePath returns the first rotated file path which doesn't exist yet, the rename must not overwrite older logs
+func freeRotatedFilePath(path string, t time.Time) (string, error) {
+	for sequence := 0; ; sequence++ {
+		rotatedPath := rotatedFilePath(path, t, sequence)
+		_, err := os.Lstat(rotatedPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return rotatedPath, nil
+		}
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+}
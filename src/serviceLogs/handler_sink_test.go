@@ -0,0 +1,111 @@
+package serviceLogs
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatedFilePath(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 20, 30, 0, time.UTC)
+
+	require.Equal(t, filepath.Join("logs", "api.20240301T102030.ndjson.gz"), rotatedFilePath(filepath.Join("logs", "api.ndjson.gz"), ts, 0))
+	require.Equal(t, "api.20240301T102030", rotatedFilePath("api", ts, 0))
+	require.Equal(t, "api.20240301T102030-2.log", rotatedFilePath("api.log", ts, 2))
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "api.log.gz")
+
+	sink, err := newFileSink(path, 10, 0)
+	require.NoError(t, err)
+
+	_, err = sink.Write([]byte("first line\n"))
+	require.NoError(t, err)
+	// the size limit was reached, the next write goes to a new file
+	_, err = sink.Write([]byte("second line\n"))
+	require.NoError(t, err)
+	require.NoError(t, sink.Close())
+
+	entries, err := os.ReadDir(filepath.Join(dir, "logs"))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "second line\n", readGzipFile(t, path))
+	for _, entry := range entries {
+		if entry.Name() != "api.log.gz" {
+			require.Equal(t, "first line\n", readGzipFile(t, filepath.Join(dir, "logs", entry.Name())))
+		}
+	}
+}
+
+func TestFileSinkRotationSameSecond(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.log")
+
+	sink, err := newFileSink(path, 5, 0)
+	require.NoError(t, err)
+
+	// every write after the first one rotates the file, all of them within the same second
+	lines := []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"}
+	for _, line := range lines {
+		_, err = sink.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(lines))
+
+	var contents []string
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		contents = append(contents, string(content))
+	}
+	require.ElementsMatch(t, lines, contents)
+}
+
+func TestFileSinkFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.log.gz")
+
+	sink, err := newFileSink(path, 0, 0)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	_, err = sink.Write([]byte("first line\n"))
+	require.NoError(t, err)
+	require.NoError(t, sink.Flush())
+
+	// the flushed messages can be read before the file is closed
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content := make([]byte, len("first line\n"))
+	_, err = io.ReadFull(r, content)
+	require.NoError(t, err)
+	require.Equal(t, "first line\n", string(content))
+}
+
+func readGzipFile(t *testing.T, path string) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(content)
+}
@@ -3,70 +3,142 @@ package serviceLogs
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
+	"github.com/zeropsio/zcli/src/backoff"
 	"github.com/zeropsio/zcli/src/i18n"
+	"github.com/zeropsio/zcli/src/uxBlock/styles"
 	"github.com/zeropsio/zerops-go/types/uuid"
 )
 
+const (
+	wsHandshakeTimeout = 10 * time.Second
+	wsWriteWait        = 5 * time.Second
+	// the connection is considered dead if no message or pong arrives within wsPongWait
+	wsPongWait   = 30 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+
+	reconnectMinDelay    = time.Second
+	reconnectMaxDelay    = 30 * time.Second
+	reconnectMaxAttempts = 10
+)
+
+// getLogStream keeps the websocket log stream open until the context is canceled.
+// Every reconnect requests a fresh log url, because the access token inside it expires.
 func (h *Handler) getLogStream(
 	ctx context.Context,
 	inputs InputValues,
 	projectId uuid.ProjectId,
-	serviceId uuid.ServiceStackId,
-	containerId uuid.ContainerId,
 	uri, query string,
 ) error {
-	url := h.updateUri(uri, query)
-
-	interrupt := make(chan os.Signal, 1)   // Channel to listen for interrupt signal to terminate gracefully
-	signal.Notify(interrupt, os.Interrupt) // Notify the interrupt channel for SIGINT
+	reconnectBackoff := backoff.New(reconnectMinDelay, reconnectMaxDelay, reconnectMaxAttempts)
 
-	conn, reps, err := websocket.DefaultDialer.Dial(url, nil)
-	if err != nil {
-		return errors.Errorf("%s %s\n", i18n.T(i18n.LogReadingFailed), err.Error())
-	}
-	defer reps.Body.Close()
-	defer conn.Close()
-
-	done := make(chan interface{}) // Channel to indicate that the receiverHandler is done
+	for {
+		received, err := h.streamLogs(ctx, h.updateUri(uri, query), inputs)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if received {
+			reconnectBackoff.Reset()
+		}
 
-	go h.receiveHandler(conn, inputs.format, done)
+		delay, ok := reconnectBackoff.Next()
+		if !ok {
+			return errors.Errorf("%s %s", i18n.T(i18n.LogReadingFailed), i18n.T(i18n.LogStreamReconnectBudgetExhausted, reconnectMaxAttempts, err))
+		}
+		h.printNotice(i18n.T(i18n.LogStreamReconnecting, err, delay.Round(time.Millisecond), reconnectBackoff.Attempt(), reconnectMaxAttempts))
 
-	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-done:
-			// if interrupted by user
+		case <-time.After(delay):
+		}
+
+		_, url, err := h.getServiceLogResData(ctx, projectId)
+		if err != nil {
 			if ctx.Err() != nil {
-				if errors.Is(ctx.Err(), context.Canceled) {
-					return nil
-				}
-				return err
-			}
-			// otherwise try to reconnect the websocket
-			err := h.printLogs(ctx, inputs, projectId, serviceId, containerId)
-			if err != nil {
-				return err
+				return nil
 			}
-		// received a SIGINT (Ctrl + C). Terminate gracefully...
-		case <-interrupt:
-			// Close the websocket connection
-			err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			// the next round of the loop will count it as a failed attempt
+			h.printNotice(err.Error())
+			continue
+		}
+		uri = getWsUrl(url)
+	}
+}
+
+// streamLogs reads a single websocket connection until it fails or the context is canceled.
+// The returned bool reports whether at least one message was received.
+func (h *Handler) streamLogs(ctx context.Context, url string, inputs InputValues) (bool, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: wsHandshakeTimeout,
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, url, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var received atomic.Bool
+	readErr := make(chan error, 1)
+	readerDone := make(chan struct{})
+
+	// the reader goroutine is the only one touching h.lastMsgId, wait for it before returning
+	defer func() {
+		conn.Close()
+		<-readerDone
+	}()
+
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	go func() {
+		defer close(readerDone)
+		for {
+			_, msg, err := conn.ReadMessage()
 			if err != nil {
-				return err
+				readErr <- err
+				return
 			}
+			_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+			received.Store(true)
+			h.printStreamLog(ctx, msg, inputs)
+		}
+	}()
 
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// close the connection gracefully and give the server a moment to confirm it
+			_ = conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(wsWriteWait),
+			)
 			select {
-			case <-done:
-			case <-time.After(time.Duration(1) * time.Second):
-				return nil
+			case <-readErr:
+			case <-time.After(time.Second):
+			}
+			return received.Load(), nil
+		case err := <-readErr:
+			return received.Load(), err
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return received.Load(), err
 			}
 		}
 	}
@@ -77,42 +149,60 @@ func (h *Handler) updateUri(uri, query string) string {
 	from := ""
 	if h.lastMsgId != "" {
 		from = fmt.Sprintf("&from=%s", h.lastMsgId)
+		h.resuming = true
 	}
 	return WSS + uri + query + from
 }
 
-func (h *Handler) receiveHandler(connection *websocket.Conn, format string, done chan interface{}) {
-	defer close(done)
+func (h *Handler) printStreamLog(ctx context.Context, data []byte, inputs InputValues) {
+	jsonData, _ := parseResponse(data)
+	// only if there is a new message coming
+	if len(jsonData.Items) == 0 {
+		return
+	}
 
-	for {
-		_, msg, err := connection.ReadMessage()
-		if err != nil {
-			// on token expiration
-			if websocket.IsCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) || websocket.IsUnexpectedCloseError(err) {
-				time.Sleep(time.Second * 5)
-				return
-			}
-			finishedByUser := strings.Contains(err.Error(), "use of closed network connection")
-			if !finishedByUser {
-				errMsg := errors.Errorf("%s %s\n", i18n.T(i18n.LogReadingFailed), err.Error())
-				fmt.Println(errMsg)
-			}
-			return
+	if h.resuming {
+		h.resuming = false
+		items, gap := dropReplayed(jsonData.Items, h.lastMsgId)
+		if gap {
+			h.printNotice(i18n.T(i18n.LogStreamPossibleGap, h.lastMsgTimestamp, jsonData.Items[0].Timestamp))
 		}
+		jsonData.Items = items
+	}
+	if len(jsonData.Items) == 0 {
+		return
+	}
+
+	// update last msg ID for ws reconnection
+	last := jsonData.Items[len(jsonData.Items)-1]
+	h.lastMsgId, h.lastMsgTimestamp = last.Id, last.Timestamp
 
-		h.printStreamLog(msg, format)
+	if h.alerts != nil {
+		h.alerts.Check(ctx, jsonData.Items)
+	}
+
+	err := parseResponseByFormat(h.sink, jsonData, inputs)
+	if err != nil {
+		h.printNotice(err.Error())
+	}
+	if err := h.sink.Flush(); err != nil {
+		h.printNotice(err.Error())
 	}
 }
 
-func (h *Handler) printStreamLog(data []byte, format string) {
-	jsonData, _ := parseResponse(data)
-	// only if there is a new message coming
-	if len(jsonData.Items) > 0 {
-		// update last msg ID for ws reconnection
-		h.lastMsgId = jsonData.Items[len(jsonData.Items)-1].Id
-		err := parseResponseByFormat(jsonData, format, "", STREAM)
-		if err != nil {
-			fmt.Println(err.Error())
+// dropReplayed removes messages which were already printed before a reconnect.
+// The stream resumed with `from` starts with the last seen message, so if it is missing
+// from the first batch, some messages were possibly lost in between.
+func dropReplayed(items []Data, lastMsgId string) ([]Data, bool) {
+	for i, item := range items {
+		if item.Id == lastMsgId {
+			return items[i+1:], false
 		}
 	}
+	return items, true
+}
+
+// printNotice writes stream status messages to stderr, so they don't mix with the log output
+func (h *Handler) printNotice(text string) {
+	fmt.Fprintln(os.Stderr, styles.WarningLine(text).String())
 }
@@ -0,0 +1,23 @@
+package serviceLogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropReplayed(t *testing.T) {
+	items := []Data{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+
+	got, gap := dropReplayed(items, "1")
+	require.False(t, gap)
+	require.Equal(t, []Data{{Id: "2"}, {Id: "3"}}, got)
+
+	got, gap = dropReplayed(items, "3")
+	require.False(t, gap)
+	require.Empty(t, got)
+
+	got, gap = dropReplayed(items, "0")
+	require.True(t, gap)
+	require.Equal(t, items, got)
+}
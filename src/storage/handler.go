@@ -2,7 +2,9 @@ package storage
 
 import (
 	"encoding/json"
+	"io"
 	"os"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -14,6 +16,15 @@ import (
 type Config struct {
 	FilePath string
 	FileMode os.FileMode
+	// SecretStore returns the store for secrets of data implementing SecretFields, without it they stay in the file.
+	// It is called once and only if there are secrets to read or write, storedName is the store of the loaded file.
+	SecretStore func(storedName string) (SecretStore, error)
+	// OpenSecretStore opens the store the secrets were saved to, if it isn't SecretStore they are migrated
+	OpenSecretStore func(name string) (SecretStore, error)
+	// Migrations upgrade files of older zcli versions, the schema version is the number of migrations
+	Migrations []Migration
+	// PlaintextSecretsErr is returned instead of writing secrets into the file if there is no secret store
+	PlaintextSecretsErr error
 }
 
 type Handler[T any] struct {
@@ -23,6 +34,13 @@ type Handler[T any] struct {
 	data T
 	//nolint:structcheck // Why: `is unused` error is false positive
 	lock sync.RWMutex
+	// secretKeys are secrets saved to the secret store, the ones missing in new data are deleted
+	//nolint:structcheck // Why: `is unused` error is false positive
+	secretKeys map[string]bool
+	//nolint:structcheck // Why: `is unused` error is false positive
+	secretStore         SecretStore
+	secretStoreErr      error
+	secretStoreResolved bool
 }
 
 func New[T any](config Config) (*Handler[T], error) {
@@ -30,10 +48,20 @@ func New[T any](config Config) (*Handler[T], error) {
 		config: config,
 	}
 
+	unlock, err := h.lockFile()
+	if err != nil {
+		return h, err
+	}
+	defer unlock()
+
 	return h, h.load()
 }
 
+// load reads the file again, the caller must hold the file lock
 func (h *Handler[T]) load() error {
+	var data T
+	h.data = data
+
 	storageFileExists, err := FileExists(h.config.FilePath)
 	if err != nil {
 		return errors.WithStack(err)
@@ -54,26 +82,237 @@ func (h *Handler[T]) load() error {
 		return errors.WithStack(err)
 	}
 	if fi.Size() == 0 {
-		return h.Clear()
+		return h.save(data)
 	}
 
-	if err := json.NewDecoder(f).Decode(&h.data); err != nil {
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	migratedContent, fileVersion, err := h.migrate(content)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(migratedContent, &data); err != nil {
 		return errors.WithMessagef(err, i18n.T(i18n.UnableToDecodeJsonFile, h.config.FilePath))
 	}
+	migrated := fileVersion != h.schemaVersion()
+	if migrated {
+		if err := h.writeBackup(content, fileVersion, data); err != nil {
+			return err
+		}
+	}
+	h.data = data
+
+	if err := h.loadSecrets(); err != nil {
+		return err
+	}
+	if migrated {
+		return h.save(h.data)
+	}
+	return nil
+}
+
+// loadSecrets reads secrets from the store they were saved to and moves them to the configured store if it differs,
+// plaintext secrets of older files are moved as well
+func (h *Handler[T]) loadSecrets() error {
+	fields, ok := any(h.data).(SecretFields[T])
+	if !ok {
+		return nil
+	}
 
+	storedName := fields.SecretStoreName()
+	if _, secrets := fields.WithoutSecrets(); storedName == "" && len(secrets) == 0 {
+		// there is nothing to read or move, the store is resolved when secrets are written
+		h.secretKeys = nil
+		return nil
+	}
+	configured, err := h.resolveSecretStore(storedName)
+	if err != nil {
+		return err
+	}
+
+	var store SecretStore
+	if storedName != "" {
+		store = configured
+		if store == nil || store.Name() != storedName {
+			if h.config.OpenSecretStore == nil {
+				return errors.New(i18n.T(i18n.UnableToReadSecrets, storedName, h.config.FilePath))
+			}
+			var err error
+			store, err = h.config.OpenSecretStore(storedName)
+			if err != nil {
+				return errors.WithMessagef(err, i18n.T(i18n.UnableToReadSecrets, storedName, h.config.FilePath))
+			}
+		}
+
+		data, err := fields.WithSecrets(getSavedSecret(store, fields.SecretKeys()))
+		if err != nil {
+			return errors.WithMessagef(err, i18n.T(i18n.UnableToReadSecrets, storedName, h.config.FilePath))
+		}
+		h.data = data
+	}
+
+	_, secrets := any(h.data).(SecretFields[T]).WithoutSecrets()
+	configuredName := ""
+	if configured != nil {
+		configuredName = configured.Name()
+	}
+	if storedName == configuredName {
+		h.secretKeys = secretKeys(secrets)
+		return nil
+	}
+	if len(secrets) == 0 && storedName == "" {
+		return nil
+	}
+
+	if err := h.save(h.data); err != nil {
+		return err
+	}
+	// the secrets are in the configured store now, the previous one is cleaned up
+	if store != nil {
+		for key := range secrets {
+			if err := store.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// encodeSecrets moves secrets of the data to the secret store and returns the data to be written into the file
+func (h *Handler[T]) encodeSecrets(data T) (T, error) {
+	fields, ok := any(data).(SecretFields[T])
+	if !ok {
+		return data, nil
+	}
+	withoutSecrets, secrets := fields.WithoutSecrets()
+	if len(secrets) == 0 && len(h.secretKeys) == 0 {
+		return withSecretStore(data, "", nil), nil
+	}
+	store, err := h.resolveSecretStore(fields.SecretStoreName())
+	if err != nil {
+		return data, err
+	}
+	if store == nil {
+		if len(secrets) > 0 && h.config.PlaintextSecretsErr != nil {
+			return data, h.config.PlaintextSecretsErr
+		}
+		return withSecretStore(data, "", nil), nil
+	}
+
+	for key, value := range secrets {
+		if err := store.Set(key, value); err != nil {
+			return data, err
+		}
+	}
+	for key := range h.secretKeys {
+		if _, exists := secrets[key]; !exists {
+			if err := store.Delete(key); err != nil {
+				return data, err
+			}
+		}
+	}
+	h.secretKeys = secretKeys(secrets)
+
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return withSecretStore(withoutSecrets, store.Name(), keys), nil
+}
+
+func withSecretStore[T any](data T, name string, keys []string) T {
+	data = any(data).(SecretFields[T]).WithSecretStoreName(name)
+	return any(data).(SecretFields[T]).WithSecretKeys(keys)
+}
+
+// getSavedSecret fails for a secret which was saved to the store but is missing there now,
+// e.g. the store was cleared, so that the login isn't lost silently
+func getSavedSecret(store SecretStore, savedKeys []string) func(key string) (string, error) {
+	saved := make(map[string]bool, len(savedKeys))
+	for _, key := range savedKeys {
+		saved[key] = true
+	}
+	return func(key string) (string, error) {
+		value, err := store.Get(key)
+		if err != nil {
+			return "", err
+		}
+		if value == "" && saved[key] {
+			return "", errors.New(i18n.T(i18n.SecretMissing, key, store.Name()))
+		}
+		return value, nil
+	}
+}
+
+// resolveSecretStore calls the configured SecretStore on the first use, detecting the store may probe the OS keyring
+func (h *Handler[T]) resolveSecretStore(storedName string) (SecretStore, error) {
+	if !h.secretStoreResolved {
+		h.secretStoreResolved = true
+		if h.config.SecretStore != nil {
+			h.secretStore, h.secretStoreErr = h.config.SecretStore(storedName)
+		}
+	}
+	return h.secretStore, h.secretStoreErr
+}
+
+// SecretStoreName returns where secrets are kept, it is empty if they are in the storage file
+func (h *Handler[T]) SecretStoreName() string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	fields, ok := any(h.data).(SecretFields[T])
+	if !ok {
+		return ""
+	}
+	store, err := h.resolveSecretStore(fields.SecretStoreName())
+	if err != nil || store == nil {
+		return ""
+	}
+	return store.Name()
+}
+
+func secretKeys(secrets map[string]string) map[string]bool {
+	keys := make(map[string]bool, len(secrets))
+	for key := range secrets {
+		keys[key] = true
+	}
+	return keys
+}
+
 func (h *Handler[T]) Clear() error {
 	h.lock.Lock()
 	defer h.lock.Unlock()
+
+	unlock, err := h.lockFile()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	var data T
 	return h.save(data)
 }
 
+// Update applies the callback to the data read under the file lock,
+// so that changes made by other zcli processes since the load are not overwritten
 func (h *Handler[T]) Update(callback func(T) T) (T, error) {
 	h.lock.Lock()
 	defer h.lock.Unlock()
+
+	unlock, err := h.lockFile()
+	if err != nil {
+		return h.data, err
+	}
+	defer unlock()
+
+	if err := h.load(); err != nil {
+		return h.data, err
+	}
+
 	h.data = callback(h.data)
 	return h.data, h.save(h.data)
 }
@@ -81,6 +320,11 @@ func (h *Handler[T]) Update(callback func(T) T) (T, error) {
 func (h *Handler[T]) save(data T) error {
 	h.data = data
 
+	data, err := h.encodeSecrets(data)
+	if err != nil {
+		return err
+	}
+
 	if err := func() error {
 		f, err := file.Open(h.config.FilePath+".new", os.O_RDWR|os.O_CREATE|os.O_TRUNC, h.config.FileMode)
 		if err != nil {
@@ -88,7 +332,15 @@ func (h *Handler[T]) save(data T) error {
 		}
 		defer f.Close()
 
-		if err := json.NewEncoder(f).Encode(data); err != nil {
+		content, err := json.Marshal(data)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		content, err = h.withSchemaVersion(content)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(content, '\n')); err != nil {
 			return errors.WithStack(err)
 		}
 		return nil
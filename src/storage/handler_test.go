@@ -2,8 +2,11 @@ package storage
 
 import (
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,4 +49,277 @@ func TestStorage(t *testing.T) {
 
 	err := os.Remove("./test")
 	require.NoError(t, err)
+	err = os.Remove("./test.lock")
+	require.NoError(t, err)
+}
+
+func TestStorageMultipleHandlers(t *testing.T) {
+	type dataObject struct {
+		Counter int
+	}
+
+	filePath := filepath.Join(t.TempDir(), "test")
+
+	// each handler stands for a separate zcli process
+	handlers := make([]*Handler[dataObject], 2)
+	for i := range handlers {
+		storage, err := New[dataObject](Config{FilePath: filePath, FileMode: 0600})
+		require.NoError(t, err)
+		handlers[i] = storage
+	}
+
+	var waitGroup sync.WaitGroup
+	for _, storage := range handlers {
+		waitGroup.Add(1)
+		go func(storage *Handler[dataObject]) {
+			defer waitGroup.Done()
+			for i := 0; i < 20; i++ {
+				_, err := storage.Update(func(data dataObject) dataObject {
+					data.Counter++
+					return data
+				})
+				require.NoError(t, err)
+			}
+		}(storage)
+	}
+	waitGroup.Wait()
+
+	storage, err := New[dataObject](Config{FilePath: filePath})
+	require.NoError(t, err)
+	require.Equal(t, 40, storage.Data().Counter)
+}
+
+type secretData struct {
+	Param     string
+	Secret    string
+	StoreName string
+	Keys      []string `json:",omitempty"`
+}
+
+func (d secretData) SecretStoreName() string {
+	return d.StoreName
+}
+
+func (d secretData) WithSecretStoreName(name string) secretData {
+	d.StoreName = name
+	return d
+}
+
+func (d secretData) SecretKeys() []string {
+	return d.Keys
+}
+
+func (d secretData) WithSecretKeys(keys []string) secretData {
+	d.Keys = keys
+	return d
+}
+
+func (d secretData) WithoutSecrets() (secretData, map[string]string) {
+	secrets := make(map[string]string)
+	if d.Secret != "" {
+		secrets["secret"] = d.Secret
+	}
+	d.Secret = ""
+	return d, secrets
+}
+
+func (d secretData) WithSecrets(get func(key string) (string, error)) (secretData, error) {
+	if d.Secret != "" {
+		return d, nil
+	}
+	secret, err := get("secret")
+	if err != nil {
+		return d, err
+	}
+	d.Secret = secret
+	return d, nil
+}
+
+type mapSecretStore map[string]string
+
+func (s mapSecretStore) Name() string {
+	return "map"
+}
+
+func (s mapSecretStore) Get(key string) (string, error) {
+	return s[key], nil
+}
+
+func (s mapSecretStore) Set(key, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapSecretStore) Delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestStorageSecrets(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test")
+
+	// plaintext secrets of older files are moved to the store
+	{
+		storage, err := New[secretData](Config{FilePath: filePath, FileMode: 0600})
+		require.NoError(t, err)
+		_, err = storage.Update(func(data secretData) secretData {
+			data.Param = "value"
+			data.Secret = "token"
+			return data
+		})
+		require.NoError(t, err)
+	}
+
+	store := mapSecretStore{}
+	{
+		storage, err := New[secretData](Config{FilePath: filePath, SecretStore: func(string) (SecretStore, error) { return store, nil }})
+		require.NoError(t, err)
+		require.Equal(t, "token", storage.Data().Secret)
+		require.Equal(t, "map", storage.SecretStoreName())
+		require.Equal(t, "token", store["secret"])
+
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		require.NotContains(t, string(content), "token")
+
+		_, err = storage.Update(func(data secretData) secretData {
+			data.Secret = ""
+			return data
+		})
+		require.NoError(t, err)
+		require.Empty(t, store)
+	}
+}
+
+func TestStorageSecretStoreLazy(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test")
+
+	resolved := 0
+	var store SecretStore
+	config := Config{
+		FilePath: filePath,
+		SecretStore: func(string) (SecretStore, error) {
+			resolved++
+			return store, nil
+		},
+		PlaintextSecretsErr: errors.New("plaintext refused"),
+	}
+
+	// without secrets the store isn't detected at all
+	storage, err := New[secretData](config)
+	require.NoError(t, err)
+	_, err = storage.Update(func(data secretData) secretData {
+		data.Param = "value"
+		return data
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, resolved)
+
+	// secrets aren't written into the file without a store
+	_, err = storage.Update(func(data secretData) secretData {
+		data.Secret = "token"
+		return data
+	})
+	require.ErrorContains(t, err, "plaintext refused")
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "token")
+
+	store = mapSecretStore{}
+	storage, err = New[secretData](config)
+	require.NoError(t, err)
+	_, err = storage.Update(func(data secretData) secretData {
+		data.Secret = "token"
+		return data
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, resolved)
+	require.Equal(t, "map", storage.SecretStoreName())
+	require.Equal(t, 2, resolved)
+}
+
+func TestStorageSecretMissing(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test")
+	store := mapSecretStore{}
+	config := Config{FilePath: filePath, SecretStore: func(string) (SecretStore, error) { return store, nil }}
+
+	storage, err := New[secretData](config)
+	require.NoError(t, err)
+	_, err = storage.Update(func(data secretData) secretData {
+		data.Secret = "token"
+		return data
+	})
+	require.NoError(t, err)
+
+	// e.g. the kernel keyring is emptied by a restart, the login must not disappear silently
+	delete(store, "secret")
+	_, err = New[secretData](config)
+	require.ErrorContains(t, err, "secret is missing")
+}
+
+func TestStorageMigrations(t *testing.T) {
+	type dataObject struct {
+		Name string
+	}
+
+	filePath := filepath.Join(t.TempDir(), "test")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"Param":"value"}`), 0600))
+
+	migrations := []Migration{
+		// the field was renamed
+		func(data map[string]any) error {
+			data["Name"] = data["Param"]
+			delete(data, "Param")
+			return nil
+		},
+	}
+
+	storage, err := New[dataObject](Config{FilePath: filePath, FileMode: 0600, Migrations: migrations})
+	require.NoError(t, err)
+	require.Equal(t, "value", storage.Data().Name)
+
+	backup, err := os.ReadFile(filePath + ".v0.bak")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Param":"value"}`, string(backup))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Name":"value","SchemaVersion":1}`, string(content))
+
+	// a file of a newer version isn't touched
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"Name":"value","SchemaVersion":2}`), 0600))
+	_, err = New[dataObject](Config{FilePath: filePath, Migrations: migrations})
+	require.Error(t, err)
+}
+
+func TestStorageMigrationBackupWithoutSecrets(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"Param":"value","Secret":"token","Nested":{"Secret":"token"}}`), 0600))
+
+	migrations := []Migration{
+		func(data map[string]any) error {
+			delete(data, "Nested")
+			return nil
+		},
+	}
+
+	store := mapSecretStore{}
+	storage, err := New[secretData](Config{
+		FilePath:    filePath,
+		FileMode:    0600,
+		SecretStore: func(string) (SecretStore, error) { return store, nil },
+		Migrations:  migrations,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "token", storage.Data().Secret)
+	require.Equal(t, "token", store["secret"])
+
+	backup, err := os.ReadFile(filePath + ".v0.bak")
+	require.NoError(t, err)
+	require.NotContains(t, string(backup), "token")
+	require.JSONEq(t, `{"Param":"value","Secret":"","Nested":{"Secret":""}}`, string(backup))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "token")
 }
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+const (
+	lockTimeout      = 10 * time.Second
+	lockPollInterval = 50 * time.Millisecond
+)
+
+// lockFile takes the advisory lock shared by all zcli processes using the file.
+// A separate lock file is used, the data file itself is replaced on every save.
+func (h *Handler[T]) lockFile() (func(), error) {
+	lockFilePath := h.config.FilePath + ".lock"
+
+	f, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, h.fileMode())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, errors.WithStack(err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, errors.New(i18n.T(i18n.UnableToLockCliData, lockFilePath))
+		}
+		time.Sleep(lockPollInterval)
+	}
+
+	return func() {
+		_ = unlock(f)
+		f.Close()
+	}, nil
+}
+
+// fileMode of the files next to the data file, the config of a read-only handler may have none
+func (h *Handler[T]) fileMode() os.FileMode {
+	if h.config.FileMode == 0 {
+		return 0600
+	}
+	return h.config.FileMode
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+// schemaVersionKey is written next to the data fields, older zcli versions ignore it
+const schemaVersionKey = "SchemaVersion"
+
+// Migration upgrades the decoded file by a single schema version, Config.Migrations[i] upgrades version i to i+1
+type Migration func(data map[string]any) error
+
+func (h *Handler[T]) schemaVersion() int {
+	return len(h.config.Migrations)
+}
+
+// migrate upgrades the file content to the current schema version and returns the version of the file,
+// the file isn't touched, it is backed up by writeBackup before the migrated content is saved
+func (h *Handler[T]) migrate(content []byte) ([]byte, int, error) {
+	if h.schemaVersion() == 0 {
+		return content, 0, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, 0, errors.WithMessagef(err, i18n.T(i18n.UnableToDecodeJsonFile, h.config.FilePath))
+	}
+	// files written before the schema version was introduced have none
+	fileVersion := 0
+	if rawVersion, exists := fields[schemaVersionKey]; exists {
+		if err := json.Unmarshal(rawVersion, &fileVersion); err != nil {
+			return nil, 0, errors.WithMessagef(err, i18n.T(i18n.UnableToDecodeJsonFile, h.config.FilePath))
+		}
+	}
+
+	if fileVersion > h.schemaVersion() {
+		return nil, 0, errors.New(i18n.T(i18n.CliDataNewerVersion, h.config.FilePath, fileVersion, h.schemaVersion()))
+	}
+	if fileVersion == h.schemaVersion() {
+		return content, fileVersion, nil
+	}
+
+	data, err := decodeJsonMap(content)
+	if err != nil {
+		return nil, 0, errors.WithMessagef(err, i18n.T(i18n.UnableToDecodeJsonFile, h.config.FilePath))
+	}
+	for version := fileVersion; version < h.schemaVersion(); version++ {
+		if err := h.config.Migrations[version](data); err != nil {
+			return nil, 0, errors.WithMessagef(err, i18n.T(i18n.CliDataMigrationFailed, h.config.FilePath, version+1))
+		}
+	}
+
+	content, err = json.Marshal(data)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	return content, fileVersion, nil
+}
+
+// writeBackup keeps the file content of an older schema version, plaintext secrets of the data are redacted,
+// so that they don't stay behind when they are moved to the secret store
+func (h *Handler[T]) writeBackup(content []byte, version int, data T) error {
+	if fields, ok := any(data).(SecretFields[T]); ok {
+		if _, secrets := fields.WithoutSecrets(); len(secrets) > 0 {
+			values := make(map[string]bool, len(secrets))
+			for _, value := range secrets {
+				values[value] = true
+			}
+			backup, err := decodeJsonMap(content)
+			if err != nil {
+				return errors.WithMessagef(err, i18n.T(i18n.UnableToDecodeJsonFile, h.config.FilePath))
+			}
+			if content, err = json.Marshal(redactValues(backup, values)); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return errors.WithStack(os.WriteFile(h.backupFilePath(version), content, h.fileMode()))
+}
+
+// redactValues empties the given string values anywhere in the decoded json
+func redactValues(value any, values map[string]bool) any {
+	switch v := value.(type) {
+	case string:
+		if values[v] {
+			return ""
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = redactValues(item, values)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValues(item, values)
+		}
+	}
+	return value
+}
+
+// decodeJsonMap keeps numbers as json.Number, so that they are written back unchanged
+func decodeJsonMap(content []byte) (map[string]any, error) {
+	var data map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// withSchemaVersion adds the current schema version to the encoded data
+func (h *Handler[T]) withSchemaVersion(content []byte) ([]byte, error) {
+	if h.schemaVersion() == 0 {
+		return content, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fields[schemaVersionKey] = json.RawMessage(strconv.Itoa(h.schemaVersion()))
+	return json.Marshal(fields)
+}
+
+func (h *Handler[T]) backupFilePath(version int) string {
+	return fmt.Sprintf("%s.v%d.bak", h.config.FilePath, version)
+}
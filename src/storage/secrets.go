@@ -0,0 +1,24 @@
+package storage
+
+// SecretStore keeps secrets outside of the storage file, e.g. in the OS keyring
+type SecretStore interface {
+	Name() string
+	// Get returns an empty string if the secret doesn't exist
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// SecretFields is implemented by data with secrets which must not be written into the storage file
+type SecretFields[T any] interface {
+	// SecretStoreName returns the store the secrets were saved to, it is empty if they are in the file
+	SecretStoreName() string
+	WithSecretStoreName(name string) T
+	// SecretKeys returns keys of the secrets saved to the store, a missing one is an error
+	SecretKeys() []string
+	WithSecretKeys(keys []string) T
+	// WithoutSecrets returns the data with all secrets removed and the secrets by their keys
+	WithoutSecrets() (T, map[string]string)
+	// WithSecrets fills secrets missing in the data
+	WithSecrets(get func(key string) (string, error)) (T, error)
+}
@@ -24,6 +24,7 @@ type UxBlocks interface {
 		auxOptions ...PromptOption,
 	) (int, error)
 	RunSpinners(ctx context.Context, spinners []*Spinner, auxOptions ...SpinnerOption) func()
+	IsTerminal() bool
 }
 
 type uxBlocks struct {
@@ -60,3 +61,8 @@ func NewBlock(
 		ctxCancel:       ctxCancel,
 	}
 }
+
+// IsTerminal reports whether the output is an interactive terminal, respecting the terminal mode env variable
+func (b *uxBlocks) IsTerminal() bool {
+	return b.isTerminal
+}
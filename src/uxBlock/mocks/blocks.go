@@ -36,6 +36,20 @@ func (m *MockUxBlocks) EXPECT() *MockUxBlocksMockRecorder {
 	return m.recorder
 }
 
+// IsTerminal mocks base method.
+func (m *MockUxBlocks) IsTerminal() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTerminal")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTerminal indicates an expected call of IsTerminal.
+func (mr *MockUxBlocksMockRecorder) IsTerminal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTerminal", reflect.TypeOf((*MockUxBlocks)(nil).IsTerminal))
+}
+
 // LogDebug mocks base method.
 func (m *MockUxBlocks) LogDebug(message string) {
 	m.ctrl.T.Helper()
@@ -130,6 +130,27 @@ func SelectColor() lipgloss.Style {
 		})
 }
 
+// ForegroundColor accepts a hex code or an ANSI color number
+func ForegroundColor(color string) lipgloss.Style {
+	return defaultStyle().Foreground(lipgloss.Color(color))
+}
+
+// SeverityColor returns a style for the syslog severity <0;7>
+func SeverityColor(severity int) lipgloss.Style {
+	switch {
+	case severity <= 3:
+		return ErrorColor()
+	case severity == 4:
+		return WarningColor()
+	case severity == 5:
+		return SelectColor()
+	case severity == 6:
+		return InfoColor()
+	default:
+		return defaultStyle().Faint(true)
+	}
+}
+
 func CobraSectionColor() lipgloss.Style {
 	return defaultStyle().
 		Foreground(lipgloss.CompleteAdaptiveColor{
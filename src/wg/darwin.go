@@ -6,7 +6,11 @@ package wg
 import (
 	"context"
 	"io"
+	"net"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -25,8 +29,10 @@ func CheckWgInstallation() error {
 	return nil
 }
 
-func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem) error {
-	data, err := defaultTemplateData(privateKey, vpnSettings)
+const hostsFilePath = "/etc/hosts"
+
+func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem, dnsMode DnsMode) error {
+	data, err := defaultTemplateData(privateKey, vpnSettings, dnsMode)
 	if err != nil {
 		return err
 	}
@@ -42,15 +48,61 @@ func DownCmd(ctx context.Context, filePath string) (err *exec.Cmd) {
 	return exec.CommandContext(ctx, "wg-quick", "down", filePath)
 }
 
+// IsInterfaceUp reports whether the tunnel exists
+func IsInterfaceUp(interfaceName string) bool {
+	_, err := net.InterfaceByName(deviceName(interfaceName))
+	return err == nil
+}
+
+// ActiveInterfaces returns names of existing zerops tunnels, wg-quick keeps a name file of every tunnel on macOS
+func ActiveInterfaces() []string {
+	nameFiles, err := filepath.Glob(filepath.Join("/var/run/wireguard", LegacyInterfaceName+"*.name"))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, nameFile := range nameFiles {
+		name := strings.TrimSuffix(filepath.Base(nameFile), ".name")
+		if IsInterfaceUp(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// deviceName returns the utun interface which wg-quick assigned to the tunnel on macOS
+func deviceName(interfaceName string) string {
+	utunName, err := os.ReadFile(filepath.Join("/var/run/wireguard", interfaceName+".name"))
+	if err != nil {
+		return interfaceName
+	}
+	return strings.TrimSpace(string(utunName))
+}
+
+// ResolveDnsMode keeps the native /etc/resolver setup for the auto mode, resolvectl, resolvconf and nmcli are used only on linux
+func ResolveDnsMode(mode DnsMode) (DnsMode, string, error) {
+	switch mode {
+	case DnsModeResolvectl, DnsModeResolvconf, DnsModeNetworkManager:
+		return "", "", errors.New(i18n.T(i18n.VpnDnsModeUnsupported, mode))
+	case DnsModeAuto:
+		return mode, "/etc/resolver", nil
+	}
+	return mode, "", nil
+}
+
+// vpnTmpl shares /etc/resolver/zerops among all tunnels, the last tunnel up owns it,
+// the file is removed on down only if it still points at the gateway of the tunnel
 var vpnTmpl = `
 [Interface]
 PrivateKey = {{.PrivateKey}}
 
 Address = {{if .AssignedIpv4Address}}{{.AssignedIpv4Address}}/32{{end}}, {{.AssignedIpv6Address}}/128
+{{- if eq .DnsMode "auto"}}
 PostUp = mkdir -p /etc/resolver 
 PostUp = echo "nameserver {{.Ipv4NetworkGateway}}" > /etc/resolver/zerops 
 PostUp = echo "domain zerops" >> /etc/resolver/zerops 
-PostDown = rm /etc/resolver/zerops 
+PostDown = grep -qx "nameserver {{.Ipv4NetworkGateway}}" /etc/resolver/zerops && rm -f /etc/resolver/zerops || true
+{{- end}}
 
 [Peer]
 PublicKey = {{.PublicKey}}
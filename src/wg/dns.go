@@ -0,0 +1,131 @@
+package wg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zeropsio/zcli/src/i18n"
+)
+
+// DnsMode says how the zerops domain is routed to the project DNS server
+type DnsMode string
+
+const (
+	DnsModeNone       DnsMode = "none"
+	DnsModeAuto       DnsMode = "auto"
+	DnsModeResolvectl DnsMode = "resolvectl"
+	DnsModeResolvconf DnsMode = "resolvconf"
+	// DnsModeNetworkManager sets the project DNS server for the zerops domain on the tunnel device by nmcli
+	DnsModeNetworkManager DnsMode = "networkmanager"
+	DnsModeHosts          DnsMode = "hosts"
+)
+
+var dnsModes = []DnsMode{DnsModeNone, DnsModeAuto, DnsModeResolvectl, DnsModeResolvconf, DnsModeNetworkManager, DnsModeHosts}
+
+// DnsModeNames are the allowed values of the --dns flag
+func DnsModeNames() []string {
+	names := make([]string, 0, len(dnsModes))
+	for _, mode := range dnsModes {
+		names = append(names, string(mode))
+	}
+	return names
+}
+
+func ParseDnsMode(value string) (DnsMode, error) {
+	for _, mode := range dnsModes {
+		if string(mode) == strings.ToLower(value) {
+			return mode, nil
+		}
+	}
+	return "", errors.New(i18n.T(i18n.VpnDnsModeInvalid))
+}
+
+// ZeropsDomain is resolved by the project DNS server
+const ZeropsDomain = "zerops"
+
+// WriteHostsEntries replaces the block of the tunnel in the hosts file, entries map host names to addresses
+func WriteHostsEntries(interfaceName string, entries map[string][]string) error {
+	content, err := os.ReadFile(hostsFilePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return writeHostsFile(content, replaceHostsBlock(content, interfaceName, entries))
+}
+
+// RemoveHostsEntries removes the block of the tunnel from the hosts file, it is a no-op if there is none
+func RemoveHostsEntries(interfaceName string) error {
+	content, err := os.ReadFile(hostsFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	return writeHostsFile(content, replaceHostsBlock(content, interfaceName, nil))
+}
+
+func writeHostsFile(content, newContent []byte) error {
+	if bytes.Equal(content, newContent) {
+		return nil
+	}
+	info, err := os.Stat(hostsFilePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// the file is rewritten in place, it is often a mount point in containers
+	if err := os.WriteFile(hostsFilePath, newContent, info.Mode().Perm()); err != nil {
+		return errors.Wrap(err, i18n.T(i18n.VpnDnsHostsWriteFailed, hostsFilePath))
+	}
+	return nil
+}
+
+func hostsBlockMarkers(interfaceName string) (string, string) {
+	return fmt.Sprintf("# zcli %s begin", interfaceName), fmt.Sprintf("# zcli %s end", interfaceName)
+}
+
+// replaceHostsBlock drops the block of the tunnel and appends a new one if there are any entries
+func replaceHostsBlock(content []byte, interfaceName string, entries map[string][]string) []byte {
+	begin, end := hostsBlockMarkers(interfaceName)
+
+	var lines []string
+	inBlock := false
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		switch strings.TrimSpace(line) {
+		case begin:
+			inBlock = true
+			continue
+		case end:
+			inBlock = false
+			continue
+		}
+		if !inBlock && line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(entries) > 0 {
+		if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+			lines[len(lines)-1] += "\n"
+		}
+		hosts := make([]string, 0, len(entries))
+		for host := range entries {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+
+		lines = append(lines, begin+"\n")
+		for _, host := range hosts {
+			for _, address := range entries[host] {
+				lines = append(lines, address+"\t"+host+"\n")
+			}
+		}
+		lines = append(lines, end+"\n")
+	}
+
+	return []byte(strings.Join(lines, ""))
+}
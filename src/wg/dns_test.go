@@ -0,0 +1,35 @@
+package wg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceHostsBlock(t *testing.T) {
+	content := []byte("127.0.0.1\tlocalhost")
+
+	written := replaceHostsBlock(content, "zerops-abc", map[string][]string{
+		"db.zerops":  {"10.0.0.2"},
+		"app.zerops": {"10.0.0.3", "fd00::3"},
+	})
+	require.Equal(t, "127.0.0.1\tlocalhost\n"+
+		"# zcli zerops-abc begin\n"+
+		"10.0.0.3\tapp.zerops\n"+
+		"fd00::3\tapp.zerops\n"+
+		"10.0.0.2\tdb.zerops\n"+
+		"# zcli zerops-abc end\n", string(written))
+
+	// blocks of other tunnels are kept
+	other := replaceHostsBlock(written, "zerops-def", map[string][]string{"db.zerops": {"10.1.0.2"}})
+	require.Contains(t, string(other), "# zcli zerops-abc begin\n")
+	require.Contains(t, string(other), "10.1.0.2\tdb.zerops\n")
+
+	removed := replaceHostsBlock(other, "zerops-abc", nil)
+	require.Equal(t, "127.0.0.1\tlocalhost\n"+
+		"# zcli zerops-def begin\n"+
+		"10.1.0.2\tdb.zerops\n"+
+		"# zcli zerops-def end\n", string(removed))
+
+	require.Equal(t, string(content), string(replaceHostsBlock(content, "zerops-abc", nil)))
+}
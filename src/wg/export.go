@@ -0,0 +1,125 @@
+package wg
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/zeropsio/zerops-go/dto/output"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const persistentKeepalive = 5
+
+// ExportConfig describes the tunnel for WireGuard setups outside of zcli
+type ExportConfig struct {
+	InterfaceName string     `json:"interfaceName"`
+	PrivateKey    string     `json:"privateKey"`
+	Addresses     []string   `json:"addresses"`
+	Dns           string     `json:"dns"`
+	DnsDomain     string     `json:"dnsDomain"`
+	Peer          ExportPeer `json:"peer"`
+}
+
+type ExportPeer struct {
+	PublicKey           string   `json:"publicKey"`
+	Endpoint            string   `json:"endpoint"`
+	AllowedIps          []string `json:"allowedIps"`
+	PersistentKeepalive int      `json:"persistentKeepalive"`
+}
+
+func NewExportConfig(interfaceName string, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem) (ExportConfig, error) {
+	data, err := defaultTemplateData(privateKey, vpnSettings, DnsModeNone)
+	if err != nil {
+		return ExportConfig{}, err
+	}
+
+	var addresses []string
+	if data["AssignedIpv4Address"] != "" {
+		addresses = append(addresses, data["AssignedIpv4Address"]+"/32")
+	}
+	if data["AssignedIpv6Address"] != "" {
+		addresses = append(addresses, data["AssignedIpv6Address"]+"/128")
+	}
+
+	var allowedIps []string
+	for _, key := range []string{"ProjectIpv4Network", "ProjectIpv6Network", "Ipv4Network", "Ipv6Network"} {
+		if data[key] != "" {
+			allowedIps = append(allowedIps, data[key])
+		}
+	}
+
+	return ExportConfig{
+		InterfaceName: interfaceName,
+		PrivateKey:    data["PrivateKey"],
+		Addresses:     addresses,
+		Dns:           data["Ipv4NetworkGateway"],
+		DnsDomain:     ZeropsDomain,
+		Peer: ExportPeer{
+			PublicKey:           data["PublicKey"],
+			Endpoint:            data["ProjectIpv4SharedEndpoint"],
+			AllowedIps:          allowedIps,
+			PersistentKeepalive: persistentKeepalive,
+		},
+	}, nil
+}
+
+// WriteNetworkManager writes the config as a NetworkManager keyfile, e.g. /etc/NetworkManager/system-connections/zerops.nmconnection
+func (c ExportConfig) WriteNetworkManager(w io.Writer) error {
+	var ipv4Address, ipv6Address string
+	for _, address := range c.Addresses {
+		if strings.Contains(address, ":") {
+			ipv6Address = address
+		} else {
+			ipv4Address = address
+		}
+	}
+
+	funcs := template.FuncMap{
+		"list": func(values []string) string {
+			return strings.Join(values, ";") + ";"
+		},
+	}
+	return template.Must(template.New("networkmanager template").Funcs(funcs).Parse(networkManagerTmpl)).Execute(w, map[string]any{
+		"Config":      c,
+		"Ipv4Address": ipv4Address,
+		"Ipv6Address": ipv6Address,
+	})
+}
+
+// the ~ prefix makes the zerops domain a routing only domain for the project DNS server
+var networkManagerTmpl = `[connection]
+id={{.Config.InterfaceName}}
+type=wireguard
+interface-name={{.Config.InterfaceName}}
+
+[wireguard]
+private-key={{.Config.PrivateKey}}
+
+[wireguard-peer.{{.Config.Peer.PublicKey}}]
+endpoint={{.Config.Peer.Endpoint}}
+allowed-ips={{list .Config.Peer.AllowedIps}}
+persistent-keepalive={{.Config.Peer.PersistentKeepalive}}
+
+[ipv4]
+{{- if .Ipv4Address}}
+method=manual
+address1={{.Ipv4Address}}
+{{- if .Config.Dns}}
+dns={{.Config.Dns}};
+dns-search=~{{.Config.DnsDomain}};
+{{- end}}
+never-default=true
+{{- else}}
+method=disabled
+{{- end}}
+
+[ipv6]
+{{- if .Ipv6Address}}
+method=manual
+address1={{.Ipv6Address}}
+never-default=true
+{{- else}}
+method=disabled
+{{- end}}
+`
@@ -0,0 +1,35 @@
+package wg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeropsio/zerops-go/dto/output"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestExportConfig(t *testing.T) {
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	var vpnSettings output.ProjectVpnItem
+	vpnSettings.Project.PublicKey = "projectKey"
+	vpnSettings.Project.Ipv4.SharedEndpoint = "1.2.3.4:51820"
+	vpnSettings.Project.Ipv4.Network.Network = "10.10.0.0/16"
+	vpnSettings.Project.Ipv4.Network.Gateway = "10.10.0.1"
+	vpnSettings.Project.Ipv6.Network.Network = "fd00:1::/64"
+	vpnSettings.Peer.Ipv6.AssignedIpAddress = "fd00:2::5"
+
+	config, err := NewExportConfig("zerops-abc", privateKey, vpnSettings)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fd00:2::5/128"}, config.Addresses)
+	require.Equal(t, []string{"10.10.0.0/16", "fd00:1::/64"}, config.Peer.AllowedIps)
+	require.Equal(t, "10.10.0.1", config.Dns)
+
+	var buf bytes.Buffer
+	require.NoError(t, config.WriteNetworkManager(&buf))
+	require.Contains(t, buf.String(), "[wireguard-peer.projectKey]\nendpoint=1.2.3.4:51820\nallowed-ips=10.10.0.0/16;fd00:1::/64;\n")
+	require.Contains(t, buf.String(), "[ipv4]\nmethod=disabled\n")
+	require.Contains(t, buf.String(), "[ipv6]\nmethod=manual\naddress1=fd00:2::5/128\n")
+}
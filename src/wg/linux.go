@@ -6,7 +6,11 @@ package wg
 import (
 	"context"
 	"io"
+	"net"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -24,8 +28,13 @@ func CheckWgInstallation() error {
 	return nil
 }
 
-func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem) error {
-	data, err := defaultTemplateData(privateKey, vpnSettings)
+const (
+	hostsFilePath      = "/etc/hosts"
+	resolvConfFilePath = "/etc/resolv.conf"
+)
+
+func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem, dnsMode DnsMode) error {
+	data, err := defaultTemplateData(privateKey, vpnSettings, dnsMode)
 	if err != nil {
 		return err
 	}
@@ -41,13 +50,104 @@ func DownCmd(ctx context.Context, filePath string) (err *exec.Cmd) {
 	return exec.CommandContext(ctx, "wg-quick", "down", filePath)
 }
 
+// IsInterfaceUp reports whether the tunnel with the given name exists
+func IsInterfaceUp(interfaceName string) bool {
+	_, err := net.InterfaceByName(interfaceName)
+	return err == nil
+}
+
+// ActiveInterfaces returns names of existing zerops tunnels, including the ones created outside of zcli
+func ActiveInterfaces() []string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, i := range interfaces {
+		if strings.HasPrefix(i.Name, LegacyInterfaceName) {
+			names = append(names, i.Name)
+		}
+	}
+	return names
+}
+
+func deviceName(interfaceName string) string {
+	return interfaceName
+}
+
+// ResolveDnsMode picks the mode for the detected resolver stack if the mode is auto,
+// the returned string describes the stack for debug logs.
+func ResolveDnsMode(mode DnsMode) (DnsMode, string, error) {
+	switch mode {
+	case DnsModeResolvectl:
+		if _, err := exec.LookPath("resolvectl"); err != nil {
+			return "", "", errors.New(i18n.T(i18n.VpnDnsToolMissing, "resolvectl", mode))
+		}
+		return mode, "systemd-resolved", nil
+	case DnsModeResolvconf:
+		if _, err := exec.LookPath("resolvconf"); err != nil {
+			return "", "", errors.New(i18n.T(i18n.VpnDnsToolMissing, "resolvconf", mode))
+		}
+		return mode, "resolvconf", nil
+	case DnsModeNetworkManager:
+		if _, err := exec.LookPath("nmcli"); err != nil {
+			return "", "", errors.New(i18n.T(i18n.VpnDnsToolMissing, "nmcli", mode))
+		}
+		return mode, "NetworkManager", nil
+	case DnsModeAuto:
+		return detectDnsMode()
+	}
+	return mode, "", nil
+}
+
+func detectDnsMode() (DnsMode, string, error) {
+	_, resolvectlErr := exec.LookPath("resolvectl")
+	if isSystemdResolved() && resolvectlErr == nil {
+		return DnsModeResolvectl, "systemd-resolved", nil
+	}
+
+	// NetworkManager without systemd-resolved keeps the DNS of every device, e.g. by its dnsmasq plugin
+	_, nmcliErr := exec.LookPath("nmcli")
+	if _, err := os.Stat("/run/NetworkManager"); err == nil && nmcliErr == nil {
+		return DnsModeNetworkManager, "NetworkManager", nil
+	}
+
+	stack := "resolv.conf"
+	// resolvconf is not picked, wg-quick registers the project DNS server exclusively for all domains
+	if _, err := exec.LookPath("resolvconf"); err == nil {
+		stack += ", resolvconf"
+	}
+
+	// a plain resolv.conf has no per-domain routing, project hosts are written into the hosts file instead
+	return DnsModeHosts, stack, nil
+}
+
+func isSystemdResolved() bool {
+	if _, err := os.Stat("/run/systemd/resolve"); err != nil {
+		return false
+	}
+	if target, err := filepath.EvalSymlinks(resolvConfFilePath); err == nil && strings.HasPrefix(target, "/run/systemd/resolve/") {
+		return true
+	}
+	content, err := os.ReadFile(resolvConfFilePath)
+	return err == nil && strings.Contains(string(content), "nameserver 127.0.0.53")
+}
+
 var vpnTmpl = `
 [Interface]
 PrivateKey = {{.PrivateKey}}
 
 Address = {{if .AssignedIpv4Address}}{{.AssignedIpv4Address}}/32{{end}}, {{.AssignedIpv6Address}}/128
+{{- if eq .DnsMode "resolvectl"}}
 PostUp = resolvectl dns %i {{.Ipv4NetworkGateway}}
 PostUp = resolvectl domain %i zerops
+PreDown = resolvectl revert %i
+{{- else if eq .DnsMode "networkmanager"}}
+PostUp = nmcli device modify %i ipv4.dns {{.Ipv4NetworkGateway}} ipv4.dns-search "~zerops"
+{{- else if eq .DnsMode "resolvconf"}}
+# wg-quick registers the DNS server by resolvconf -x, it resolves all domains until the tunnel is down
+DNS = {{.Ipv4NetworkGateway}}, zerops
+{{- end}}
 
 [Peer]
 PublicKey = {{.PublicKey}}
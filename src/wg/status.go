@@ -0,0 +1,56 @@
+package wg
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl"
+)
+
+// HandshakeTimeout is the age of the last handshake after which WireGuard stops using the session
+const HandshakeTimeout = 3 * time.Minute
+
+type DeviceStatus struct {
+	Endpoint      string
+	LastHandshake time.Time
+	ReceiveBytes  int64
+	TransmitBytes int64
+	AllowedIps    []string
+}
+
+// IsConnected reports whether the peer completed a handshake recently
+func (s DeviceStatus) IsConnected() bool {
+	return !s.LastHandshake.IsZero() && time.Since(s.LastHandshake) < HandshakeTimeout
+}
+
+// ReadDeviceStatus reads the project peer of the tunnel, it needs root privileges on most systems
+func ReadDeviceStatus(interfaceName string) (DeviceStatus, error) {
+	client, err := wgctrl.New()
+	if err != nil {
+		return DeviceStatus{}, errors.WithStack(err)
+	}
+	defer client.Close()
+
+	device, err := client.Device(deviceName(interfaceName))
+	if err != nil {
+		return DeviceStatus{}, errors.WithStack(err)
+	}
+	// zcli configures exactly one peer, the project
+	if len(device.Peers) == 0 {
+		return DeviceStatus{}, errors.Errorf("device %s has no peer", interfaceName)
+	}
+	peer := device.Peers[0]
+
+	status := DeviceStatus{
+		LastHandshake: peer.LastHandshakeTime,
+		ReceiveBytes:  peer.ReceiveBytes,
+		TransmitBytes: peer.TransmitBytes,
+	}
+	if peer.Endpoint != nil {
+		status.Endpoint = peer.Endpoint.String()
+	}
+	for _, allowedIp := range peer.AllowedIPs {
+		status.AllowedIps = append(status.AllowedIps, allowedIp.String())
+	}
+	return status, nil
+}
@@ -0,0 +1,124 @@
+package wg
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/zeropsio/zerops-go/dto/output"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/tun/netstack"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const DefaultMtu = 1420
+
+// UserspaceTunnel is a WireGuard tunnel running inside the zcli process on top of a gVisor network stack.
+// It needs neither root privileges nor wg-quick, the project network is reachable only through DialContext.
+type UserspaceTunnel struct {
+	device *device.Device
+	net    *netstack.Net
+}
+
+func NewUserspaceTunnel(
+	privateKey wgtypes.Key,
+	vpnSettings output.ProjectVpnItem,
+	mtu int,
+	debugLogf func(format string, args ...any),
+) (*UserspaceTunnel, error) {
+	data, err := defaultTemplateData(privateKey, vpnSettings, DnsModeNone)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []netip.Addr
+	for _, key := range []string{"AssignedIpv4Address", "AssignedIpv6Address"} {
+		if data[key] == "" {
+			continue
+		}
+		addr, err := netip.ParseAddr(data[key])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", key)
+		}
+		addresses = append(addresses, addr)
+	}
+
+	var dnsServers []netip.Addr
+	if data["Ipv4NetworkGateway"] != "" {
+		gateway, err := netip.ParseAddr(data["Ipv4NetworkGateway"])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse Ipv4NetworkGateway")
+		}
+		dnsServers = append(dnsServers, gateway)
+	}
+
+	tunDevice, tunNet, err := netstack.CreateNetTUN(addresses, dnsServers, mtu)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	logger := &device.Logger{Verbosef: debugLogf, Errorf: debugLogf}
+	dev := device.NewDevice(tunDevice, conn.NewDefaultBind(), logger)
+
+	uapiConfig, err := userspaceConfig(privateKey, data)
+	if err != nil {
+		dev.Close()
+		return nil, err
+	}
+	if err := dev.IpcSet(uapiConfig); err != nil {
+		dev.Close()
+		return nil, errors.WithStack(err)
+	}
+	if err := dev.Up(); err != nil {
+		dev.Close()
+		return nil, errors.WithStack(err)
+	}
+
+	return &UserspaceTunnel{
+		device: dev,
+		net:    tunNet,
+	}, nil
+}
+
+// DialContext connects to an address in the project network, *.zerops names are resolved by the project DNS
+func (t *UserspaceTunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return t.net.DialContext(ctx, network, address)
+}
+
+func (t *UserspaceTunnel) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return t.net.LookupContextHost(ctx, host)
+}
+
+func (t *UserspaceTunnel) Close() {
+	t.device.Close()
+}
+
+// userspaceConfig renders the template data into the wireguard-go UAPI format, keys are hex encoded there
+func userspaceConfig(privateKey wgtypes.Key, data map[string]string) (string, error) {
+	publicKey, err := wgtypes.ParseKey(data["PublicKey"])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse project public key")
+	}
+
+	endpoint, err := net.ResolveUDPAddr("udp", data["ProjectIpv4SharedEndpoint"])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve project endpoint")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "private_key=%s\n", hex.EncodeToString(privateKey[:]))
+	fmt.Fprintf(&b, "public_key=%s\n", hex.EncodeToString(publicKey[:]))
+	fmt.Fprintf(&b, "endpoint=%s\n", endpoint.String())
+	fmt.Fprintf(&b, "persistent_keepalive_interval=%d\n", 5)
+	for _, key := range []string{"ProjectIpv4Network", "ProjectIpv6Network", "Ipv4Network", "Ipv6Network"} {
+		if data[key] != "" {
+			fmt.Fprintf(&b, "allowed_ip=%s\n", data[key])
+		}
+	}
+	return b.String(), nil
+}
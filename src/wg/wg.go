@@ -1,14 +1,51 @@
 package wg
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/zeropsio/zerops-go/dto/output"
+	"github.com/zeropsio/zerops-go/types/uuid"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
-func defaultTemplateData(privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem) (map[string]string, error) {
+const (
+	interfacePrefix = "zerops-"
+	// LegacyInterfaceName was used for the single tunnel before every project got its own interface
+	LegacyInterfaceName = "zerops"
+	// interface names are limited to 15 characters on linux
+	maxInterfaceNameLength = 15
+)
+
+// InterfaceName derives a stable interface name of the project tunnel, it is also the name of its config file
+func InterfaceName(projectId uuid.ProjectId) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, string(projectId))
+	if len(name) > maxInterfaceNameLength-len(interfacePrefix) {
+		name = name[:maxInterfaceNameLength-len(interfacePrefix)]
+	}
+	return interfacePrefix + name
+}
+
+// UniqueInterfaceName returns InterfaceName unless another project uses it, a hash of the project id is used then,
+// usedBy maps interface names of existing tunnels to their projects
+func UniqueInterfaceName(projectId uuid.ProjectId, usedBy map[string]uuid.ProjectId) string {
+	name := InterfaceName(projectId)
+	if owner, used := usedBy[name]; !used || owner == projectId {
+		return name
+	}
+	hash := sha256.Sum256([]byte(projectId))
+	return interfacePrefix + hex.EncodeToString(hash[:])[:maxInterfaceNameLength-len(interfacePrefix)]
+}
+
+func defaultTemplateData(privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem, dnsMode DnsMode) (map[string]string, error) {
 	projectIpv4Network := ""
 	if vpnSettings.Project.Ipv4.Network.Network != "" {
 		_, n, err := net.ParseCIDR(string(vpnSettings.Project.Ipv4.Network.Network))
@@ -56,5 +93,6 @@ func defaultTemplateData(privateKey wgtypes.Key, vpnSettings output.ProjectVpnIt
 		"Ipv4Network":               ipv4Network,
 		"Ipv6Network":               ipv6Network,
 		"ProjectIpv4SharedEndpoint": string(vpnSettings.Project.Ipv4.SharedEndpoint),
+		"DnsMode":                   string(dnsMode),
 	}, nil
 }
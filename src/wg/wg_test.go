@@ -0,0 +1,23 @@
+package wg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeropsio/zerops-go/types/uuid"
+)
+
+func TestUniqueInterfaceName(t *testing.T) {
+	first, second := uuid.ProjectId("abcdefgh_first"), uuid.ProjectId("abcdefgh_second")
+	require.Equal(t, "zerops-abcdefgh", InterfaceName(first))
+	require.Equal(t, InterfaceName(first), InterfaceName(second))
+
+	usedBy := map[string]uuid.ProjectId{"zerops-abcdefgh": first}
+	require.Equal(t, "zerops-abcdefgh", UniqueInterfaceName(first, usedBy))
+
+	// the second project with the same id prefix gets a hashed name
+	name := UniqueInterfaceName(second, usedBy)
+	require.NotEqual(t, "zerops-abcdefgh", name)
+	require.Len(t, name, maxInterfaceNameLength)
+	require.Equal(t, name, UniqueInterfaceName(second, usedBy))
+}
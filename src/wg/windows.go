@@ -6,7 +6,9 @@ package wg
 import (
 	"context"
 	"io"
+	"net"
 	"os/exec"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -24,8 +26,10 @@ func CheckWgInstallation() error {
 	return nil
 }
 
-func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem) error {
-	data, err := defaultTemplateData(privateKey, vpnSettings)
+const hostsFilePath = `C:\Windows\System32\drivers\etc\hosts`
+
+func GenerateConfig(f io.Writer, privateKey wgtypes.Key, vpnSettings output.ProjectVpnItem, dnsMode DnsMode) error {
+	data, err := defaultTemplateData(privateKey, vpnSettings, dnsMode)
 	if err != nil {
 		return err
 	}
@@ -41,12 +45,50 @@ func DownCmd(ctx context.Context, filePath string) (err *exec.Cmd) {
 	return exec.CommandContext(ctx, "wireguard", "/uninstalltunnelservice", filePath)
 }
 
+// IsInterfaceUp reports whether the tunnel with the given name exists
+func IsInterfaceUp(interfaceName string) bool {
+	_, err := net.InterfaceByName(interfaceName)
+	return err == nil
+}
+
+// ActiveInterfaces returns names of existing zerops tunnels, including the ones created outside of zcli
+func ActiveInterfaces() []string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, i := range interfaces {
+		if strings.HasPrefix(i.Name, LegacyInterfaceName) {
+			names = append(names, i.Name)
+		}
+	}
+	return names
+}
+
+func deviceName(interfaceName string) string {
+	return interfaceName
+}
+
+// ResolveDnsMode keeps the native WireGuard DNS setup for the auto mode, resolvectl, resolvconf and nmcli are used only on linux
+func ResolveDnsMode(mode DnsMode) (DnsMode, string, error) {
+	switch mode {
+	case DnsModeResolvectl, DnsModeResolvconf, DnsModeNetworkManager:
+		return "", "", errors.New(i18n.T(i18n.VpnDnsModeUnsupported, mode))
+	case DnsModeAuto:
+		return mode, "WireGuard DNS", nil
+	}
+	return mode, "", nil
+}
+
 var vpnTmpl = `
 [Interface]
 PrivateKey = {{.PrivateKey}}
 
 Address = {{if .AssignedIpv4Address}}{{.AssignedIpv4Address}}/32{{end}}, {{.AssignedIpv6Address}}/128
+{{- if eq .DnsMode "auto"}}
 DNS = {{.Ipv4NetworkGateway}}, zerops
+{{- end}}
 ### Alternative DNS
 # PostUp = powershell -command "Add-DnsClientNrptRule -Namespace 'zerops' -NameServers '{{.Ipv4NetworkGateway}}'"
 # PostDown = powershell -command "Get-DnsClientNrptRule | Where { $_.Namespace -match '.*zerops' } | Remove-DnsClientNrptRule -force"